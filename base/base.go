@@ -16,6 +16,7 @@ type Sketch struct {
 	setup     SetupFunc
 	render    RenderFunc
 	framesDir string
+	tilesDir  string
 }
 
 func NewSketch(setup SetupFunc, render RenderFunc) *Sketch {
@@ -23,6 +24,7 @@ func NewSketch(setup SetupFunc, render RenderFunc) *Sketch {
 		setup:     setup,
 		render:    render,
 		framesDir: "frames", // Must Exist!!!
+		tilesDir:  "tiles",
 	}
 }
 
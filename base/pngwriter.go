@@ -0,0 +1,108 @@
+package base
+
+import (
+	"bufio"
+	"compress/zlib"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+)
+
+// idatSize is the amount of compressed data held before it is written out as an IDAT chunk.
+const idatSize = 1 << 16
+
+// pngWriter writes an 8 bit RGBA png one row at a time, so the whole image never needs to be in memory.
+type pngWriter struct {
+	w      *bufio.Writer
+	zw     *zlib.Writer
+	idat   []byte
+	filter []byte
+}
+
+func newPNGWriter(w io.Writer, width, height int) (*pngWriter, error) {
+	p := &pngWriter{
+		w:      bufio.NewWriter(w),
+		filter: []byte{0},
+	}
+	_, err := p.w.WriteString("\x89PNG\r\n\x1a\n")
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 13)
+	binary.BigEndian.PutUint32(header[0:], uint32(width))
+	binary.BigEndian.PutUint32(header[4:], uint32(height))
+	header[8] = 8 // bit depth
+	header[9] = 6 // color type: rgba
+	err = p.writeChunk("IHDR", header)
+	if err != nil {
+		return nil, err
+	}
+	p.zw = zlib.NewWriter(p)
+	return p, nil
+}
+
+// WriteRow writes a row of un-premultiplied r, g, b, a bytes.
+func (p *pngWriter) WriteRow(row []byte) error {
+	// each row starts with a filter type byte. 0 is no filtering.
+	_, err := p.zw.Write(p.filter)
+	if err != nil {
+		return err
+	}
+	_, err = p.zw.Write(row)
+	return err
+}
+
+// Close finishes the compressed data and writes the final chunks.
+func (p *pngWriter) Close() error {
+	err := p.zw.Close()
+	if err != nil {
+		return err
+	}
+	err = p.flushIDAT()
+	if err != nil {
+		return err
+	}
+	err = p.writeChunk("IEND", nil)
+	if err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
+
+// Write collects compressed data from the zlib writer, writing it out in IDAT chunks.
+func (p *pngWriter) Write(data []byte) (int, error) {
+	p.idat = append(p.idat, data...)
+	if len(p.idat) >= idatSize {
+		err := p.flushIDAT()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (p *pngWriter) flushIDAT() error {
+	if len(p.idat) == 0 {
+		return nil
+	}
+	err := p.writeChunk("IDAT", p.idat)
+	p.idat = p.idat[:0]
+	return err
+}
+
+func (p *pngWriter) writeChunk(name string, data []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(name))
+	crc.Write(data)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	for _, b := range [][]byte{length[:], []byte(name), data, sum[:]} {
+		_, err := p.w.Write(b)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
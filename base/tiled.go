@@ -0,0 +1,137 @@
+package base
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/bit101/blgo"
+	cairo "github.com/bit101/go-cairo"
+)
+
+// RenderTiled renders an image too large to fit in memory as a grid of tiles.
+// The setup and render functions are called once per tile with the full width and height,
+// and the surface pre-translated, so sketches draw as if to a single surface.
+// Tiles are written to the tiles directory and then stitched into out.png.
+// Each tile's surface is destroyed once written, so only one is held in memory at a time.
+func (s *Sketch) RenderTiled(width, height, tileSize, t float64) error {
+	outFileName := "out.png"
+	s.width = width
+	s.height = height
+	cols := int(math.Ceil(width / tileSize))
+	rows := int(math.Ceil(height / tileSize))
+	err := os.MkdirAll(s.tilesDir, 0755)
+	if err != nil {
+		return err
+	}
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			fmt.Printf("\rtile %d of %d", row*cols+col+1, rows*cols)
+			x := float64(col) * tileSize
+			y := float64(row) * tileSize
+			s.surface = blgo.NewSurface(math.Min(tileSize, width-x), math.Min(tileSize, height-y))
+			s.surface.Translate(-x, -y)
+			s.setup(s.surface, s.width, s.height)
+			s.render(s.surface, s.width, s.height, t)
+			fileName := tileFileName(s.tilesDir, col, row)
+			status := s.surface.WriteToPNG(fileName)
+			s.surface.Destroy()
+			s.surface = nil
+			if status != cairo.StatusSuccess {
+				fmt.Println()
+				return fmt.Errorf("writing tile %s: cairo status %v", fileName, status)
+			}
+		}
+	}
+	fmt.Println()
+	return StitchTiles(s.tilesDir, outFileName, cols, rows)
+}
+
+// StitchTiles combines a grid of png tiles, as written by RenderTiled, into a single png file.
+// Only one row of tiles is held in memory at a time, and the output is written row by row.
+func StitchTiles(tilesDir, outFileName string, cols, rows int) error {
+	width, height := 0, 0
+	for col := 0; col < cols; col++ {
+		tile, err := readTile(tilesDir, col, 0)
+		if err != nil {
+			return err
+		}
+		width += tile.Bounds().Dx()
+	}
+	for row := 0; row < rows; row++ {
+		tile, err := readTile(tilesDir, 0, row)
+		if err != nil {
+			return err
+		}
+		height += tile.Bounds().Dy()
+	}
+
+	file, err := os.Create(outFileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer, err := newPNGWriter(file, width, height)
+	if err != nil {
+		return err
+	}
+
+	line := make([]byte, width*4)
+	for row := 0; row < rows; row++ {
+		tiles := make([]*image.NRGBA, cols)
+		for col := 0; col < cols; col++ {
+			tiles[col], err = readTile(tilesDir, col, row)
+			if err != nil {
+				return err
+			}
+		}
+		for y := 0; y < tiles[0].Bounds().Dy(); y++ {
+			offset := 0
+			for _, tile := range tiles {
+				start := tile.PixOffset(0, y)
+				offset += copy(line[offset:], tile.Pix[start:start+tile.Bounds().Dx()*4])
+			}
+			err = writer.WriteRow(line)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func tileFileName(tilesDir string, col, row int) string {
+	return filepath.Join(tilesDir, fmt.Sprintf("tile_%0.4d_%0.4d.png", row, col))
+}
+
+// readTile decodes a tile into an image with un-premultiplied alpha.
+func readTile(tilesDir string, col, row int) (*image.NRGBA, error) {
+	file, err := os.Open(tileFileName(tilesDir, col, row))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	if nrgba, ok := img.(*image.NRGBA); ok && nrgba.Bounds().Min == (image.Point{}) {
+		return nrgba, nil
+	}
+	bounds := img.Bounds()
+	nrgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			nrgba.Set(x, y, color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)))
+		}
+	}
+	return nrgba, nil
+}
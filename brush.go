@@ -0,0 +1,86 @@
+package blgo
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/noise"
+	"github.com/bit101/blgo/random"
+)
+
+// WidthFunc returns the width of a brush stroke at t, from 0.0 at the start of a path to 1.0 at its end.
+type WidthFunc func(t float64) float64
+
+// Brush draws the outline of a brush stroke along a path of points, with a width for each point.
+// Widths can come from a WidthFunc with BrushWidths, or from pen pressure.
+func (s *Surface) Brush(points []*geom.Point, widths []float64) {
+	s.NewSubPath()
+	s.Path(geom.BrushOutline(points, widths))
+	s.ClosePath()
+}
+
+// FillBrush draws a filled brush stroke along a path of points, with a width for each point.
+func (s *Surface) FillBrush(points []*geom.Point, widths []float64) {
+	s.Brush(points, widths)
+	s.Fill()
+}
+
+// FillBrushFunc draws a filled brush stroke along a path of points, with its width set by a WidthFunc.
+func (s *Surface) FillBrushFunc(points []*geom.Point, width WidthFunc) {
+	s.FillBrush(points, BrushWidths(points, width))
+}
+
+// FillCalligraphy draws a filled brush stroke along a path, as made by a flat calligraphic nib held at an angle.
+// Lines across the nib are width wide, and lines along it are thin.
+func (s *Surface) FillCalligraphy(points []*geom.Point, width, thin, angle float64) {
+	s.NewSubPath()
+	s.Path(geom.NibOutline(points, width, thin, angle))
+	s.ClosePath()
+	s.Fill()
+}
+
+// BrushWidths returns the width of a brush stroke at each point of a path, from a WidthFunc.
+func BrushWidths(points []*geom.Point, width WidthFunc) []float64 {
+	params := geom.PathParams(points)
+	widths := make([]float64, len(points))
+	for i, t := range params {
+		widths[i] = width(t)
+	}
+	return widths
+}
+
+// ConstantWidth returns a WidthFunc with the same width all along the stroke.
+func ConstantWidth(width float64) WidthFunc {
+	return func(t float64) float64 {
+		return width
+	}
+}
+
+// TaperWidth returns a WidthFunc that swells smoothly from nothing to a width,
+// over the given fraction of the stroke at each end.
+func TaperWidth(width, taper float64) WidthFunc {
+	return func(t float64) float64 {
+		if taper <= 0 {
+			return width
+		}
+		d := math.Min(math.Min(t, 1-t)/taper, 1)
+		return width * math.Sin(d*math.Pi/2)
+	}
+}
+
+// NoiseWidths varies the widths of a brush stroke with perlin noise, for an uneven, inked look.
+// Amount is how much widths vary, from 0.0 for not at all to 1.0 for between none and double.
+// Scale sets how quickly the noise changes along the path.
+// Each call starts at a new place in the noise, so two strokes along the same path still differ.
+func NoiseWidths(points []*geom.Point, widths []float64, amount, scale float64) []float64 {
+	offset := random.FloatRange(0, 1000)
+	result := make([]float64, len(widths))
+	for i, w := range widths {
+		if i >= len(points) {
+			break
+		}
+		p := points[i]
+		result[i] = math.Max(0, w*(1+amount*noise.Perlin(p.X*scale, p.Y*scale, offset)))
+	}
+	return result
+}
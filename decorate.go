@@ -0,0 +1,120 @@
+package blgo
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+)
+
+// Symbol draws a small shape around the origin, pointing along the x axis.
+// PlaceAlongPath moves and rotates symbols into place along a path.
+// The path helpers here all walk straight lines between points.
+// Curves can be walked by flattening them first with geom.FlattenBezierPath.
+type Symbol func(s *Surface)
+
+// DashedPath draws a path of points as dashes, following a pattern of alternating dash and gap lengths
+// which starts offset along the path.
+func (s *Surface) DashedPath(points []*geom.Point, pattern []float64, offset float64) {
+	s.Paths(geom.Dash(points, pattern, offset))
+}
+
+// StrokeDashedPath draws a stroked, dashed path of points.
+func (s *Surface) StrokeDashedPath(points []*geom.Point, pattern []float64, offset float64) {
+	s.DashedPath(points, pattern, offset)
+	s.Stroke()
+}
+
+// DottedPath draws filled dots of a given radius spaced evenly along a path of points.
+func (s *Surface) DottedPath(points []*geom.Point, spacing, radius float64) {
+	for _, sample := range geom.SamplePath(points, spacing, 0) {
+		s.Circle(sample.Point.X, sample.Point.Y, radius)
+		s.Fill()
+	}
+}
+
+// PlaceAlongPath draws a symbol at points spaced evenly along a path, starting offset from its start,
+// with each one turned to follow the path.
+func (s *Surface) PlaceAlongPath(points []*geom.Point, spacing, offset float64, symbol Symbol) {
+	for _, sample := range geom.SamplePath(points, spacing, offset) {
+		s.Save()
+		s.Translate(sample.Point.X, sample.Point.Y)
+		s.Rotate(sample.Angle)
+		symbol(s)
+		s.Restore()
+	}
+}
+
+// TickSymbol returns a Symbol that draws a stroked line across a path.
+func TickSymbol(length float64) Symbol {
+	return func(s *Surface) {
+		s.MoveTo(0, -length/2)
+		s.LineTo(0, length/2)
+		s.Stroke()
+	}
+}
+
+// ChevronSymbol returns a Symbol that draws a stroked arrow pointing along a path.
+func ChevronSymbol(size float64) Symbol {
+	return func(s *Surface) {
+		s.MoveTo(-size/2, -size/2)
+		s.LineTo(0, 0)
+		s.LineTo(-size/2, size/2)
+		s.Stroke()
+	}
+}
+
+// ShapeSymbol returns a Symbol that draws a filled polygon, given in coordinates around the origin.
+func ShapeSymbol(polygon geom.Polygon) Symbol {
+	return func(s *Surface) {
+		s.FillPolygonPath(polygon)
+	}
+}
+
+////////////////////////////////////////
+// Arrows
+////////////////////////////////////////
+
+// Arrowhead draws a filled arrowhead with its tip at x, y, pointing at an angle.
+func (s *Surface) Arrowhead(x, y, angle, size float64) {
+	s.Save()
+	s.Translate(x, y)
+	s.Rotate(angle)
+	s.MoveTo(0, 0)
+	s.LineTo(-size, -size*0.4)
+	s.LineTo(-size, size*0.4)
+	s.ClosePath()
+	s.Fill()
+	s.Restore()
+}
+
+// StrokeArrowPath draws a stroked path of points with arrowheads at either or both ends.
+// The path is shortened under each arrowhead, so wide lines don't show past the tip.
+// Each arrowhead points along the path over the length it covers, so repeated end points
+// and the short segments of flattened curves don't turn it.
+func (s *Surface) StrokeArrowPath(points []*geom.Point, size float64, start, end bool) {
+	if len(points) < 2 {
+		return
+	}
+	length := geom.PathLength(points)
+	if length == 0 {
+		return
+	}
+	from, to := 0.0, length
+	if start {
+		from = math.Min(size/2, length/2)
+	}
+	if end {
+		to = math.Max(length-size/2, length/2)
+	}
+	s.NewSubPath()
+	s.Path(geom.PathSection(points, from, to))
+	s.Stroke()
+	if start {
+		tip, back := points[0], geom.PointAtDistance(points, math.Min(size/2, length)).Point
+		s.Arrowhead(tip.X, tip.Y, math.Atan2(tip.Y-back.Y, tip.X-back.X), size)
+	}
+	if end {
+		tip, back := points[len(points)-1], geom.PointAtDistance(points, math.Max(length-size/2, 0)).Point
+		s.Arrowhead(tip.X, tip.Y, math.Atan2(tip.Y-back.Y, tip.X-back.X), size)
+	}
+}
@@ -7,13 +7,15 @@ import (
 	"github.com/bit101/blgo/blmath"
 	"github.com/bit101/blgo/floodfill"
 	"github.com/bit101/blgo/geom"
+	cairo "github.com/bit101/go-cairo"
 )
 
 // Plot draws a single pixel.
 func (s *Surface) Plot(p *geom.Point) {
 	s.Save()
 	s.Translate(p.X, p.Y)
-	s.FillRectangle(-0.5, -0.5, 1, 1)
+	s.Rectangle(-0.5, -0.5, 1, 1)
+	s.Fill()
 	s.Restore()
 }
 
@@ -23,6 +25,11 @@ func (s *Surface) Plot(p *geom.Point) {
 
 // Line draws a line between two x, y points.
 func (s *Surface) Line(x0, y0, x1, y1 float64) {
+	if s.sketchy != nil {
+		s.roughLine(x0, y0, x1, y1)
+		s.Stroke()
+		return
+	}
 	s.MoveTo(x0, y0)
 	s.LineTo(x1, y1)
 	s.Stroke()
@@ -35,9 +42,7 @@ func (s *Surface) LineThrough(x0, y0, x1, y1, overlap float64) {
 	s.Rotate(math.Atan2(y1-y0, x1-x0))
 	p2 := math.Hypot(x0-x1, y0-y1)
 
-	s.MoveTo(-overlap, 0)
-	s.LineTo(p2+overlap, 0)
-	s.Stroke()
+	s.Line(-overlap, 0, p2+overlap, 0)
 	s.Restore()
 }
 
@@ -50,9 +55,7 @@ func (s *Surface) Ray(x, y, angle, offset, length float64) {
 	s.Save()
 	s.Translate(x, y)
 	s.Rotate(angle)
-	s.MoveTo(offset, 0)
-	s.LineTo(offset+length, 0)
-	s.Stroke()
+	s.Line(offset, 0, offset+length, 0)
 	s.Restore()
 }
 
@@ -62,12 +65,22 @@ func (s *Surface) Ray(x, y, angle, offset, length float64) {
 
 // FillRectangle draws a filled rectancle.
 func (s *Surface) FillRectangle(x, y, w, h float64) {
+	if s.sketchy != nil {
+		s.hachure(geom.Polygon{rectanglePoints(x, y, w, h)})
+		s.Stroke()
+		return
+	}
 	s.Rectangle(x, y, w, h)
 	s.Fill()
 }
 
 // StrokeRectangle draws a stroked rectangle.
 func (s *Surface) StrokeRectangle(x, y, w, h float64) {
+	if s.sketchy != nil {
+		s.roughPolyline(rectanglePoints(x, y, w, h), true)
+		s.Stroke()
+		return
+	}
 	s.Rectangle(x, y, w, h)
 	s.Stroke()
 }
@@ -91,12 +104,34 @@ func (s *Surface) RoundRectangle(x, y, w, h, r float64) {
 
 // StrokeRoundRectangle draws a stroked, rounded rectangle.
 func (s *Surface) StrokeRoundRectangle(x, y, w, h, r float64) {
+	if s.sketchy != nil {
+		s.roughLine(x+r, y, x+w-r, y)
+		s.roughCurve(ellipsePoints(x+w-r, y+r, r, r, -blmath.HalfPi, 0.0), false)
+		s.roughLine(x+w, y+r, x+w, y+h-r)
+		s.roughCurve(ellipsePoints(x+w-r, y+h-r, r, r, 0.0, blmath.HalfPi), false)
+		s.roughLine(x+w-r, y+h, x+r, y+h)
+		s.roughCurve(ellipsePoints(x+r, y+h-r, r, r, blmath.HalfPi, math.Pi), false)
+		s.roughLine(x, y+h-r, x, y+r)
+		s.roughCurve(ellipsePoints(x+r, y+r, r, r, math.Pi, math.Pi*1.5), false)
+		s.Stroke()
+		return
+	}
 	s.RoundRectangle(x, y, w, h, r)
 	s.Stroke()
 }
 
 // FillRoundRectangle draws a filled, rounded rectangle.
 func (s *Surface) FillRoundRectangle(x, y, w, h, r float64) {
+	if s.sketchy != nil {
+		var ring []*geom.Point
+		ring = append(ring, ellipsePoints(x+w-r, y+r, r, r, -blmath.HalfPi, 0.0)...)
+		ring = append(ring, ellipsePoints(x+w-r, y+h-r, r, r, 0.0, blmath.HalfPi)...)
+		ring = append(ring, ellipsePoints(x+r, y+h-r, r, r, blmath.HalfPi, math.Pi)...)
+		ring = append(ring, ellipsePoints(x+r, y+r, r, r, math.Pi, math.Pi*1.5)...)
+		s.hachure(geom.Polygon{ring})
+		s.Stroke()
+		return
+	}
 	s.RoundRectangle(x, y, w, h, r)
 	s.Fill()
 }
@@ -112,12 +147,20 @@ func (s *Surface) Circle(x, y, r float64) {
 
 // FillCircle draws a filled circle.
 func (s *Surface) FillCircle(x, y, r float64) {
+	if s.sketchy != nil {
+		s.FillEllipse(x, y, r, r)
+		return
+	}
 	s.Circle(x, y, r)
 	s.Fill()
 }
 
 // StrokeCircle draws a stroked circle.
 func (s *Surface) StrokeCircle(x, y, r float64) {
+	if s.sketchy != nil {
+		s.StrokeEllipse(x, y, r, r)
+		return
+	}
 	s.Circle(x, y, r)
 	s.Stroke()
 }
@@ -140,12 +183,24 @@ func (s *Surface) Ellipse(x, y, xr, yr float64) {
 
 // FillEllipse draws a filled ellipse.
 func (s *Surface) FillEllipse(x, y, xr, yr float64) {
+	if s.sketchy != nil {
+		points := ellipsePoints(x, y, xr, yr, 0, blmath.TwoPi)
+		s.hachure(geom.Polygon{points[:len(points)-1]})
+		s.Stroke()
+		return
+	}
 	s.Ellipse(x, y, xr, yr)
 	s.Fill()
 }
 
 // StrokeEllipse draws a stroked ellipse.
 func (s *Surface) StrokeEllipse(x, y, xr, yr float64) {
+	if s.sketchy != nil {
+		points := ellipsePoints(x, y, xr, yr, 0, blmath.TwoPi)
+		s.roughCurve(points[:len(points)-1], true)
+		s.Stroke()
+		return
+	}
 	s.Ellipse(x, y, xr, yr)
 	s.Stroke()
 }
@@ -163,12 +218,22 @@ func (s *Surface) Path(points []*geom.Point) {
 
 // FillPath draws a filled path of points.
 func (s *Surface) FillPath(points []*geom.Point) {
+	if s.sketchy != nil {
+		s.hachure(geom.Polygon{points})
+		s.Stroke()
+		return
+	}
 	s.Path(points)
 	s.Fill()
 }
 
 // StrokePath draws a stroked path of points.
 func (s *Surface) StrokePath(points []*geom.Point, close bool) {
+	if s.sketchy != nil {
+		s.roughPolyline(points, close)
+		s.Stroke()
+		return
+	}
 	s.Path(points)
 	if close {
 		s.ClosePath()
@@ -176,6 +241,71 @@ func (s *Surface) StrokePath(points []*geom.Point, close bool) {
 	s.Stroke()
 }
 
+////////////////////////////////////////
+// PolygonPath
+////////////////////////////////////////
+
+// PolygonPath draws each ring of a geom.Polygon as a closed sub path.
+func (s *Surface) PolygonPath(polygon geom.Polygon) {
+	for _, ring := range polygon {
+		s.NewSubPath()
+		s.Path(ring)
+		s.ClosePath()
+	}
+}
+
+// FillPolygonPath draws a filled geom.Polygon, leaving holes empty.
+func (s *Surface) FillPolygonPath(polygon geom.Polygon) {
+	if s.sketchy != nil {
+		s.hachure(polygon)
+		s.Stroke()
+		return
+	}
+	s.PolygonPath(polygon)
+	s.Save()
+	s.SetFillRule(cairo.FillRuleEvenOdd)
+	s.Fill()
+	s.Restore()
+}
+
+// StrokePolygonPath draws a stroked geom.Polygon.
+func (s *Surface) StrokePolygonPath(polygon geom.Polygon) {
+	if s.sketchy != nil {
+		for _, ring := range polygon {
+			s.roughPolyline(ring, true)
+		}
+		s.Stroke()
+		return
+	}
+	s.PolygonPath(polygon)
+	s.Stroke()
+}
+
+////////////////////////////////////////
+// Paths
+////////////////////////////////////////
+
+// Paths draws a number of separate open paths, such as the lines of a geom.Hatch fill.
+func (s *Surface) Paths(paths [][]*geom.Point) {
+	for _, path := range paths {
+		s.NewSubPath()
+		s.Path(path)
+	}
+}
+
+// StrokePaths draws a number of separate stroked paths.
+func (s *Surface) StrokePaths(paths [][]*geom.Point) {
+	if s.sketchy != nil {
+		for _, path := range paths {
+			s.roughPolyline(path, false)
+		}
+		s.Stroke()
+		return
+	}
+	s.Paths(paths)
+	s.Stroke()
+}
+
 ////////////////////////////////////////
 // Polygon
 ////////////////////////////////////////
@@ -196,12 +326,20 @@ func (s *Surface) Polygon(x, y, r float64, sides int, rotation float64) {
 
 // StrokePolygon draws a stroked polygon.
 func (s *Surface) StrokePolygon(x, y, r float64, sides int, rotation float64) {
+	if s.sketchy != nil {
+		s.StrokePolygonPath(geom.RegularPolygon(x, y, r, sides, rotation))
+		return
+	}
 	s.Polygon(x, y, r, sides, rotation)
 	s.Stroke()
 }
 
 // FillPolygon draws a filled polygon.
 func (s *Surface) FillPolygon(x, y, r float64, sides int, rotation float64) {
+	if s.sketchy != nil {
+		s.FillPolygonPath(geom.RegularPolygon(x, y, r, sides, rotation))
+		return
+	}
 	s.Polygon(x, y, r, sides, rotation)
 	s.Fill()
 }
@@ -229,12 +367,20 @@ func (s *Surface) Star(x, y, r0, r1 float64, points int, rotation float64) {
 
 // StrokeStar draws a stroked star.
 func (s *Surface) StrokeStar(x, y, r0, r1 float64, points int, rotation float64) {
+	if s.sketchy != nil {
+		s.StrokePolygonPath(geom.StarPolygon(x, y, r0, r1, points, rotation))
+		return
+	}
 	s.Star(x, y, r0, r1, points, rotation)
 	s.Stroke()
 }
 
 // FillStar draws a filled star.
 func (s *Surface) FillStar(x, y, r0, r1 float64, points int, rotation float64) {
+	if s.sketchy != nil {
+		s.FillPolygonPath(geom.StarPolygon(x, y, r0, r1, points, rotation))
+		return
+	}
 	s.Star(x, y, r0, r1, points, rotation)
 	s.Fill()
 }
@@ -249,6 +395,15 @@ func (s *Surface) Splat(
 	numNodes int,
 	radius, innerRadius, variation float64,
 ) {
+	s.MultiLoop(splatPoints(x, y, numNodes, radius, innerRadius, variation))
+}
+
+// splatPoints returns the points that a splat's smooth curve is drawn between.
+func splatPoints(
+	x, y float64,
+	numNodes int,
+	radius, innerRadius, variation float64,
+) []*geom.Point {
 	var points []*geom.Point
 	slice := blmath.TwoPi / float64(numNodes*2)
 	angle := 0.0
@@ -265,12 +420,11 @@ func (s *Surface) Splat(
 		points = append(points, makePoint(angle+slice*(1.0+curve), innerRadius+radiusRange*0.8))
 		angle += slice * 2.0
 	}
-
-	s.Save()
-	s.Translate(x, y)
-	s.MultiLoop(points)
-	s.Restore()
-
+	for _, point := range points {
+		point.X += x
+		point.Y += y
+	}
+	return points
 }
 
 func makePoint(angle, radius float64) *geom.Point {
@@ -286,6 +440,10 @@ func (s *Surface) StrokeSplat(
 	numNodes int,
 	radius, innerRadius, variation float64,
 ) {
+	if s.sketchy != nil {
+		s.StrokeMultiLoop(splatPoints(x, y, numNodes, radius, innerRadius, variation))
+		return
+	}
 	s.Splat(x, y, numNodes, radius, innerRadius, variation)
 	s.Stroke()
 }
@@ -296,6 +454,10 @@ func (s *Surface) FillSplat(
 	numNodes int,
 	radius, innerRadius, variation float64,
 ) {
+	if s.sketchy != nil {
+		s.FillMultiLoop(splatPoints(x, y, numNodes, radius, innerRadius, variation))
+		return
+	}
 	s.Splat(x, y, numNodes, radius, innerRadius, variation)
 	s.Fill()
 }
@@ -358,12 +520,21 @@ func (s *Surface) Heart(x, y, w, h, r float64) {
 
 // FillHeart draws a filled heart shape.
 func (s *Surface) FillHeart(x, y, w, h, r float64) {
+	if s.sketchy != nil {
+		s.FillPolygonPath(geom.HeartPolygon(x, y, w, h, r))
+		return
+	}
 	s.Heart(x, y, w, h, r)
 	s.Fill()
 }
 
 // StrokeHeart draws a stroked heart shape.
 func (s *Surface) StrokeHeart(x, y, w, h, r float64) {
+	if s.sketchy != nil {
+		s.roughCurve(geom.HeartPolygon(x, y, w, h, r)[0], true)
+		s.Stroke()
+		return
+	}
 	s.Heart(x, y, w, h, r)
 	s.Stroke()
 }
@@ -374,8 +545,10 @@ func (s *Surface) StrokeHeart(x, y, w, h, r float64) {
 
 // Points draws a number of points.
 func (s *Surface) Points(points []*geom.Point, radius float64) {
+	// always solid, as points are too small for a sketchy fill.
 	for _, point := range points {
-		s.FillCircle(point.X, point.Y, radius)
+		s.Circle(point.X, point.Y, radius)
+		s.Fill()
 	}
 }
 
@@ -385,6 +558,12 @@ func (s *Surface) Points(points []*geom.Point, radius float64) {
 
 // StrokeCurveTo draws a stroked curve.
 func (s *Surface) StrokeCurveTo(x0, y0, x1, y1, x2, y2 float64) {
+	if s.sketchy != nil {
+		px, py := s.GetCurrentPoint()
+		s.roughCurve(curvePoints(px, py, x0, y0, x1, y1, x2, y2), false)
+		s.Stroke()
+		return
+	}
 	s.CurveTo(x0, y0, x1, y1, x2, y2)
 	s.Stroke()
 }
@@ -407,6 +586,12 @@ func (s *Surface) QuadraticCurveTo(x0, y0, x1, y1 float64) {
 
 // StrokeQuadraticCurveTo draws a stroked quadratic curve.
 func (s *Surface) StrokeQuadraticCurveTo(x0, y0, x1, y1 float64) {
+	if s.sketchy != nil {
+		px, py := s.GetCurrentPoint()
+		s.roughCurve(quadraticPoints(px, py, x0, y0, x1, y1), false)
+		s.Stroke()
+		return
+	}
 	s.QuadraticCurveTo(x0, y0, x1, y1)
 	s.Stroke()
 }
@@ -438,6 +623,11 @@ func (s *Surface) MultiCurve(points []*geom.Point) {
 
 // StrokeMultiCurve draws a stroked curve between a set of points.
 func (s *Surface) StrokeMultiCurve(points []*geom.Point) {
+	if s.sketchy != nil {
+		s.roughCurve(multiCurvePoints(points), false)
+		s.Stroke()
+		return
+	}
 	s.MultiCurve(points)
 	s.Stroke()
 }
@@ -465,16 +655,170 @@ func (s *Surface) MultiLoop(points []*geom.Point) {
 
 // FillMultiLoop draws a filled, smooth, closed curve between a set of points.
 func (s *Surface) FillMultiLoop(points []*geom.Point) {
+	if s.sketchy != nil {
+		s.hachure(geom.Polygon{multiLoopPoints(points)})
+		s.Stroke()
+		return
+	}
 	s.MultiLoop(points)
 	s.Fill()
 }
 
 // StrokeMultiLoop draws a stroked, smooth, closed curve between a set of points.
 func (s *Surface) StrokeMultiLoop(points []*geom.Point) {
+	if s.sketchy != nil {
+		s.roughCurve(multiLoopPoints(points), true)
+		s.Stroke()
+		return
+	}
 	s.MultiLoop(points)
 	s.Stroke()
 }
 
+////////////////////////////////////////
+// BezierPath
+////////////////////////////////////////
+
+// BezierPath draws a path of joined cubic bezier curves: a start point followed by
+// two control points and an end point for each curve.
+func (s *Surface) BezierPath(path []*geom.Point) {
+	if len(path) == 0 {
+		return
+	}
+	s.MoveTo(path[0].X, path[0].Y)
+	for i := 1; i+2 < len(path); i += 3 {
+		s.CurveTo(path[i].X, path[i].Y, path[i+1].X, path[i+1].Y, path[i+2].X, path[i+2].Y)
+	}
+}
+
+// StrokeBezierPath draws a stroked path of joined cubic bezier curves.
+func (s *Surface) StrokeBezierPath(path []*geom.Point) {
+	if s.sketchy != nil {
+		s.roughBezierPath(path, false)
+		s.Stroke()
+		return
+	}
+	s.BezierPath(path)
+	s.Stroke()
+}
+
+////////////////////////////////////////
+// Splines
+////////////////////////////////////////
+
+// CatmullRom draws a Catmull-Rom spline passing through a set of points. See geom.CatmullRom.
+func (s *Surface) CatmullRom(points []*geom.Point, alpha, tension float64, closed bool) {
+	s.BezierPath(geom.CatmullRom(points, alpha, tension, closed))
+	if closed {
+		s.ClosePath()
+	}
+}
+
+// StrokeCatmullRom draws a stroked Catmull-Rom spline.
+func (s *Surface) StrokeCatmullRom(points []*geom.Point, alpha, tension float64, closed bool) {
+	if s.sketchy != nil {
+		s.roughBezierPath(geom.CatmullRom(points, alpha, tension, closed), closed)
+		s.Stroke()
+		return
+	}
+	s.CatmullRom(points, alpha, tension, closed)
+	s.Stroke()
+}
+
+// BSpline draws a uniform cubic B-spline, pulled towards a set of points. See geom.BSpline.
+func (s *Surface) BSpline(points []*geom.Point, closed bool) {
+	s.BezierPath(geom.BSpline(points, closed))
+	if closed {
+		s.ClosePath()
+	}
+}
+
+// StrokeBSpline draws a stroked B-spline.
+func (s *Surface) StrokeBSpline(points []*geom.Point, closed bool) {
+	if s.sketchy != nil {
+		s.roughBezierPath(geom.BSpline(points, closed), closed)
+		s.Stroke()
+		return
+	}
+	s.BSpline(points, closed)
+	s.Stroke()
+}
+
+// HermiteSpline draws a cubic Hermite spline through a set of points, with a tangent for each. See geom.HermiteSpline.
+// Nothing is drawn if there are fewer tangents than points.
+func (s *Surface) HermiteSpline(points, tangents []*geom.Point, closed bool) {
+	path := geom.HermiteSpline(points, tangents, closed)
+	if path == nil {
+		return
+	}
+	s.BezierPath(path)
+	if closed {
+		s.ClosePath()
+	}
+}
+
+// StrokeHermiteSpline draws a stroked Hermite spline.
+func (s *Surface) StrokeHermiteSpline(points, tangents []*geom.Point, closed bool) {
+	path := geom.HermiteSpline(points, tangents, closed)
+	if path == nil {
+		return
+	}
+	if s.sketchy != nil {
+		s.roughBezierPath(path, closed)
+		s.Stroke()
+		return
+	}
+	s.BezierPath(path)
+	if closed {
+		s.ClosePath()
+	}
+	s.Stroke()
+}
+
+////////////////////////////////////////
+// Delaunay and Voronoi
+////////////////////////////////////////
+
+// Triangulation draws each edge of a geom.Triangulation as a sub path.
+func (s *Surface) Triangulation(t *geom.Triangulation) {
+	for _, edge := range t.Edges() {
+		p0, p1 := t.Points[edge[0]], t.Points[edge[1]]
+		s.NewSubPath()
+		s.MoveTo(p0.X, p0.Y)
+		s.LineTo(p1.X, p1.Y)
+	}
+}
+
+// StrokeTriangulation draws a stroked geom.Triangulation.
+func (s *Surface) StrokeTriangulation(t *geom.Triangulation) {
+	if s.sketchy != nil {
+		for _, edge := range t.Edges() {
+			p0, p1 := t.Points[edge[0]], t.Points[edge[1]]
+			s.roughLine(p0.X, p0.Y, p1.X, p1.Y)
+		}
+		s.Stroke()
+		return
+	}
+	s.Triangulation(t)
+	s.Stroke()
+}
+
+// VoronoiCells draws each cell of a Voronoi diagram, as returned by geom.Voronoi, as a closed sub path.
+func (s *Surface) VoronoiCells(cells [][]*geom.Point) {
+	s.PolygonPath(cells)
+}
+
+// FillVoronoiCells draws filled Voronoi cells. Fill cells in different colors with FillPath.
+func (s *Surface) FillVoronoiCells(cells [][]*geom.Point) {
+	s.FillPolygonPath(cells)
+}
+
+// StrokeVoronoiCells draws stroked Voronoi cells.
+// Like StrokePolygonPath, in sketchy mode each cell is drawn separately, so shared edges are drawn twice.
+func (s *Surface) StrokeVoronoiCells(cells [][]*geom.Point) {
+	s.StrokePolygonPath(cells)
+}
+
 ////////////////////////////////////////
 // FloodFill
 ////////////////////////////////////////
@@ -490,6 +834,16 @@ func (s *Surface) FloodFill(x, y, r, g, b, threshold float64) {
 
 // Grid draws a grid.
 func (s *Surface) Grid(x, y, w, h, xres, yres float64) {
+	if s.sketchy != nil {
+		for xx := x; xx <= x+w; xx += xres {
+			s.roughLine(xx, y, xx, y+h)
+		}
+		for yy := y; yy <= y+h; yy += yres {
+			s.roughLine(x, yy, x+w, yy)
+		}
+		s.Stroke()
+		return
+	}
 	xx := x
 	yy := y
 	for xx <= x+w {
@@ -511,6 +865,14 @@ func (s *Surface) Grid(x, y, w, h, xres, yres float64) {
 
 // HexGrid draws a hexagonal grid
 func (s *Surface) HexGrid(x, y, w, h, res0, res1 float64) {
+	for _, p := range hexGridCenters(x, y, w, h, res0) {
+		s.Polygon(p.X, p.Y, res1, 6, math.Pi/2)
+	}
+}
+
+// hexGridCenters returns the centers of the cells of a hexagonal grid covering a rectangle.
+func hexGridCenters(x, y, w, h, res0 float64) []*geom.Point {
+	var centers []*geom.Point
 	sin60r := math.Sin(math.Pi/3.0) * res0
 	xInc := 2.0 * sin60r
 	yInc := res0 * 1.5
@@ -518,7 +880,7 @@ func (s *Surface) HexGrid(x, y, w, h, res0, res1 float64) {
 
 	for yy := y; yy < y+h+yInc; yy += yInc {
 		for xx := x; xx < x+w+xInc; xx += xInc {
-			s.Polygon(xx+offset, yy, res1, 6, math.Pi/2)
+			centers = append(centers, geom.NewPoint(xx+offset, yy))
 		}
 		if offset == 0 {
 			offset = sin60r
@@ -526,9 +888,31 @@ func (s *Surface) HexGrid(x, y, w, h, res0, res1 float64) {
 			offset = 0
 		}
 	}
+	return centers
+}
+
+// sketchyHexGrid draws each cell of a hexagonal grid roughly, clipped to its rectangle.
+func (s *Surface) sketchyHexGrid(x, y, w, h, res0, res1 float64, fill bool) {
+	s.Save()
+	s.Rectangle(x, y, w, h)
+	s.Clip()
+	for _, p := range hexGridCenters(x, y, w, h, res0) {
+		hex := geom.RegularPolygon(p.X, p.Y, res1, 6, math.Pi/2)
+		if fill {
+			s.hachure(hex)
+		} else {
+			s.roughPolyline(hex[0], true)
+		}
+	}
+	s.Stroke()
+	s.Restore()
 }
 
 func (s *Surface) FillHexGrid(x, y, w, h, res0, res1 float64) {
+	if s.sketchy != nil {
+		s.sketchyHexGrid(x, y, w, h, res0, res1, true)
+		return
+	}
 	s.Save()
 	s.Rectangle(x, y, w, h)
 	s.Clip()
@@ -538,6 +922,10 @@ func (s *Surface) FillHexGrid(x, y, w, h, res0, res1 float64) {
 }
 
 func (s *Surface) StrokeHexGrid(x, y, w, h, res0, res1 float64) {
+	if s.sketchy != nil {
+		s.sketchyHexGrid(x, y, w, h, res0, res1, false)
+		return
+	}
 	s.Save()
 	s.Rectangle(x, y, w, h)
 	s.Clip()
@@ -0,0 +1,91 @@
+package filter
+
+import (
+	"math"
+
+	"github.com/bit101/blgo"
+)
+
+// GaussianBlur blurs a surface using a gaussian kernel with the given radius.
+func GaussianBlur(surface *blgo.Surface, radius float64, edge EdgeMode) {
+	src := readBuffer(surface, true)
+	writeBuffer(surface, gaussianBlur(src, radius, edge), true)
+}
+
+// BoxBlur blurs a surface by averaging the pixels within the given radius.
+func BoxBlur(surface *blgo.Surface, radius int, edge EdgeMode) {
+	if radius < 1 {
+		return
+	}
+	weights := make([]float64, radius*2+1)
+	for i := range weights {
+		weights[i] = 1.0 / float64(len(weights))
+	}
+	src := readBuffer(surface, true)
+	writeBuffer(surface, separable(src, weights, edge), true)
+}
+
+// UnsharpMask sharpens a surface by subtracting a gaussian blurred copy of it.
+// Amount controls the strength of the effect. 1.0 is a good starting point.
+func UnsharpMask(surface *blgo.Surface, radius, amount float64, edge EdgeMode) {
+	src := readBuffer(surface, true)
+	blurred := gaussianBlur(src, radius, edge)
+	for i, v := range src.pix {
+		blurred.pix[i] = v + (v-blurred.pix[i])*amount
+	}
+	writeBuffer(surface, blurred, true)
+}
+
+// gaussianBlur returns a blurred copy of a buffer.
+func gaussianBlur(src *buffer, radius float64, edge EdgeMode) *buffer {
+	if radius <= 0 {
+		dst := newBuffer(src.width, src.height)
+		copy(dst.pix, src.pix)
+		return dst
+	}
+	return separable(src, gaussianWeights(radius), edge)
+}
+
+// gaussianWeights returns a normalized one dimensional gaussian kernel.
+// The radius is treated as three standard deviations.
+func gaussianWeights(radius float64) []float64 {
+	sigma := radius / 3
+	size := int(math.Ceil(radius))
+	weights := make([]float64, size*2+1)
+	sum := 0.0
+	for i := range weights {
+		x := float64(i - size)
+		weights[i] = math.Exp(-x * x / (2 * sigma * sigma))
+		sum += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return weights
+}
+
+// separable applies a one dimensional kernel horizontally, then vertically.
+func separable(src *buffer, weights []float64, edge EdgeMode) *buffer {
+	center := len(weights) / 2
+	pass := func(src *buffer, dx, dy int) *buffer {
+		dst := newBuffer(src.width, src.height)
+		parallel(src.height, func(y int) {
+			for x := 0; x < src.width; x++ {
+				var sum [4]float64
+				for k, weight := range weights {
+					offset := k - center
+					i := src.index(x+offset*dx, y+offset*dy, edge)
+					if i < 0 {
+						continue
+					}
+					for c := 0; c < 4; c++ {
+						sum[c] += src.pix[i+c] * weight
+					}
+				}
+				copy(dst.pix[(y*src.width+x)*4:], sum[:])
+			}
+		})
+		return dst
+	}
+	return pass(pass(src, 1, 0), 0, 1)
+}
@@ -0,0 +1,156 @@
+// Package filter provides image filters that operate on the pixel data of a blgo.Surface.
+package filter
+
+import (
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/bit101/blgo"
+)
+
+// EdgeMode defines how pixels beyond the edges of a surface are sampled.
+type EdgeMode int
+
+const (
+	// EdgeClamp uses the nearest pixel on the edge of the surface.
+	EdgeClamp EdgeMode = iota
+	// EdgeWrap wraps around to the opposite side of the surface.
+	EdgeWrap
+	// EdgeTransparent treats pixels outside the surface as transparent.
+	EdgeTransparent
+)
+
+// buffer holds a copy of surface pixel data as r, g, b, a floats from 0.0 to 1.0.
+type buffer struct {
+	width, height int
+	pix           []float64
+}
+
+func newBuffer(width, height int) *buffer {
+	return &buffer{
+		width:  width,
+		height: height,
+		pix:    make([]float64, width*height*4),
+	}
+}
+
+// readBuffer copies the pixel data of a surface into a new buffer.
+// If premultiplied is false, color values are divided by alpha.
+func readBuffer(surface *blgo.Surface, premultiplied bool) *buffer {
+	w, h, stride := surface.GetWidth(), surface.GetHeight(), surface.GetStride()
+	surface.Flush()
+	data := surface.GetData()
+	buf := newBuffer(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			src := y*stride + x*4
+			dst := (y*w + x) * 4
+			a := float64(data[src+3]) / 255.0
+			r := float64(data[src+2]) / 255.0
+			g := float64(data[src+1]) / 255.0
+			b := float64(data[src]) / 255.0
+			if !premultiplied && a > 0 {
+				r, g, b = r/a, g/a, b/a
+			}
+			buf.pix[dst] = r
+			buf.pix[dst+1] = g
+			buf.pix[dst+2] = b
+			buf.pix[dst+3] = a
+		}
+	}
+	return buf
+}
+
+// writeBuffer copies a buffer back into the pixel data of a surface.
+// If premultiplied is false, color values are multiplied by alpha.
+func writeBuffer(surface *blgo.Surface, buf *buffer, premultiplied bool) {
+	stride := surface.GetStride()
+	surface.Flush()
+	data := surface.GetData()
+	for y := 0; y < buf.height; y++ {
+		for x := 0; x < buf.width; x++ {
+			src := (y*buf.width + x) * 4
+			dst := y*stride + x*4
+			a := clamp(buf.pix[src+3])
+			r, g, b := buf.pix[src], buf.pix[src+1], buf.pix[src+2]
+			if !premultiplied {
+				r, g, b = r*a, g*a, b*a
+			}
+			// premultiplied color can never exceed alpha.
+			data[dst] = toByte(math.Min(clamp(b), a))
+			data[dst+1] = toByte(math.Min(clamp(g), a))
+			data[dst+2] = toByte(math.Min(clamp(r), a))
+			data[dst+3] = toByte(a)
+		}
+	}
+	surface.SetData(data)
+	surface.MarkDirty()
+}
+
+// index returns the index of the pixel at x, y, applying the edge mode.
+// Returns -1 for transparent pixels.
+func (b *buffer) index(x, y int, edge EdgeMode) int {
+	if x < 0 || x >= b.width || y < 0 || y >= b.height {
+		switch edge {
+		case EdgeWrap:
+			x = wrap(x, b.width)
+			y = wrap(y, b.height)
+		case EdgeTransparent:
+			return -1
+		default:
+			x = clampInt(x, 0, b.width-1)
+			y = clampInt(y, 0, b.height-1)
+		}
+	}
+	return (y*b.width + x) * 4
+}
+
+// parallel splits the rows of a buffer into bands and calls rowFunc for each row concurrently.
+func parallel(height int, rowFunc func(y int)) {
+	if height == 0 {
+		return
+	}
+	workers := runtime.NumCPU()
+	if workers > height {
+		workers = height
+	}
+	band := (height + workers - 1) / workers
+	var wg sync.WaitGroup
+	for y0 := 0; y0 < height; y0 += band {
+		y1 := y0 + band
+		if y1 > height {
+			y1 = height
+		}
+		wg.Add(1)
+		go func(y0, y1 int) {
+			defer wg.Done()
+			for y := y0; y < y1; y++ {
+				rowFunc(y)
+			}
+		}(y0, y1)
+	}
+	wg.Wait()
+}
+
+func wrap(value, size int) int {
+	return ((value % size) + size) % size
+}
+
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+func clamp(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
+
+func toByte(value float64) byte {
+	return byte(math.Round(value * 255))
+}
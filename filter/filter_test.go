@@ -0,0 +1,199 @@
+package filter
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/bit101/blgo"
+)
+
+// testBuffer returns a buffer whose channels count up from 0.0 in steps of 0.01, so every value is different.
+func testBuffer(width, height int) *buffer {
+	buf := newBuffer(width, height)
+	for i := range buf.pix {
+		buf.pix[i] = float64(i) / 100
+	}
+	return buf
+}
+
+func fillBuffer(width, height int, value float64) *buffer {
+	buf := newBuffer(width, height)
+	for i := range buf.pix {
+		buf.pix[i] = value
+	}
+	return buf
+}
+
+func TestBufferIndex(t *testing.T) {
+	buf := newBuffer(3, 2)
+	tests := []struct {
+		x, y  int
+		edge  EdgeMode
+		index int
+	}{
+		{2, 1, EdgeClamp, 20},
+		{2, 1, EdgeTransparent, 20},
+		{-1, -1, EdgeClamp, 0},
+		{5, 1, EdgeClamp, 20},
+		{1, 9, EdgeClamp, 16},
+		{-1, 0, EdgeWrap, 8},
+		{3, 2, EdgeWrap, 0},
+		{-4, -3, EdgeWrap, 20},
+		{-1, 0, EdgeTransparent, -1},
+		{0, 2, EdgeTransparent, -1},
+	}
+	for _, test := range tests {
+		if index := buf.index(test.x, test.y, test.edge); index != test.index {
+			t.Errorf("index(%d, %d, %d) = %d, want %d", test.x, test.y, test.edge, index, test.index)
+		}
+	}
+}
+
+func TestReadWriteBuffer(t *testing.T) {
+	w, h := 3, 2
+	surface := blgo.NewSurface(float64(w), float64(h))
+	buf := newBuffer(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := (y*w + x) * 4
+			buf.pix[i] = 1
+			buf.pix[i+1] = 0.5
+			buf.pix[i+2] = 0
+			buf.pix[i+3] = float64(y*w+x+1) / 10
+		}
+	}
+	writeBuffer(surface, buf, false)
+
+	// rows start a stride apart, in premultiplied b, g, r, a order.
+	stride := surface.GetStride()
+	data := surface.GetData()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			a := float64(y*w+x+1) / 10
+			i := y*stride + x*4
+			want := []byte{0, toByte(a * 0.5), toByte(a), toByte(a)}
+			for c := range want {
+				if data[i+c] != want[c] {
+					t.Errorf("pixel %d, %d byte %d is %d, want %d", x, y, c, data[i+c], want[c])
+				}
+			}
+		}
+	}
+
+	// reading back divides out alpha again.
+	read := readBuffer(surface, false)
+	for i, v := range read.pix {
+		if math.Abs(v-buf.pix[i]) > 0.02 {
+			t.Errorf("read value %d is %f, want %f", i, v, buf.pix[i])
+		}
+	}
+	read = readBuffer(surface, true)
+	if r, a := read.pix[0], read.pix[3]; math.Abs(r-a) > 1e-9 {
+		t.Errorf("premultiplied red is %f, want alpha %f", r, a)
+	}
+}
+
+func TestParallel(t *testing.T) {
+	parallel(0, func(y int) {
+		t.Errorf("row %d called for zero height", y)
+	})
+
+	rows := make([]int, 37)
+	parallel(len(rows), func(y int) {
+		rows[y]++
+	})
+	for y, count := range rows {
+		if count != 1 {
+			t.Errorf("row %d called %d times, want 1", y, count)
+		}
+	}
+}
+
+func TestConvolve(t *testing.T) {
+	src := testBuffer(4, 3)
+	identity := NewKernel(3, 3, []float64{0, 0, 0, 0, 1, 0, 0, 0, 0})
+	dst := convolve(src, identity, EdgeClamp)
+	for i, v := range dst.pix {
+		if v != src.pix[i] {
+			t.Fatalf("identity kernel changed value %d from %f to %f", i, src.pix[i], v)
+		}
+	}
+
+	// a kernel weighted to the right moves every pixel one to the left, wrapping around.
+	shift := NewKernel(3, 1, []float64{0, 0, 1})
+	dst = convolve(src, shift, EdgeWrap)
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			i, j := (y*4+x)*4, (y*4+(x+1)%4)*4
+			if dst.pix[i] != src.pix[j] {
+				t.Errorf("shifted pixel %d, %d is %f, want %f", x, y, dst.pix[i], src.pix[j])
+			}
+		}
+	}
+
+	// averaging a solid buffer keeps it solid, unless the edges are transparent.
+	box := NewKernel(3, 3, []float64{1, 1, 1, 1, 1, 1, 1, 1, 1}).Normalize()
+	dst = convolve(fillBuffer(4, 3, 1), box, EdgeClamp)
+	for i, v := range dst.pix {
+		if math.Abs(v-1) > 1e-9 {
+			t.Fatalf("clamped box value %d is %f, want 1", i, v)
+		}
+	}
+	dst = convolve(fillBuffer(4, 3, 1), box, EdgeTransparent)
+	if corner, middle := dst.pix[3], dst.pix[(1*4+1)*4+3]; math.Abs(corner-4.0/9) > 1e-9 || math.Abs(middle-1) > 1e-9 {
+		t.Errorf("transparent edge alphas are %f and %f, want %f and 1", corner, middle, 4.0/9)
+	}
+
+	// bias is added to the color, and alpha is kept when preserved.
+	identity.Bias = 0.25
+	identity.PreserveAlpha = true
+	dst = convolve(src, identity, EdgeClamp)
+	for i := 0; i < len(dst.pix); i += 4 {
+		if math.Abs(dst.pix[i]-src.pix[i]-0.25) > 1e-9 || dst.pix[i+3] != src.pix[i+3] {
+			t.Fatalf("biased pixel %v, want color plus 0.25 and alpha %f", dst.pix[i:i+4], src.pix[i+3])
+		}
+	}
+}
+
+func TestConvolveBadKernel(t *testing.T) {
+	defer func() {
+		err := recover()
+		if err == nil || !strings.Contains(err.(string), "has 8 values, want 9") {
+			t.Errorf("short kernel panicked with %v", err)
+		}
+	}()
+	Convolve(blgo.NewSurface(2, 2), NewKernel(3, 3, make([]float64, 8)), EdgeClamp)
+}
+
+func TestBlur(t *testing.T) {
+	weights := gaussianWeights(4.5)
+	sum := 0.0
+	for i, weight := range weights {
+		sum += weight
+		if mirror := weights[len(weights)-1-i]; weight != mirror {
+			t.Errorf("weight %d is %f, want %f to match the other side", i, weight, mirror)
+		}
+	}
+	if len(weights) != 11 || math.Abs(sum-1) > 1e-9 {
+		t.Errorf("gaussian has %d weights adding up to %f, want 11 adding up to 1", len(weights), sum)
+	}
+
+	// blurring spreads a single pixel out without changing its total.
+	src := newBuffer(9, 9)
+	center := (4*9 + 4) * 4
+	src.pix[center+3] = 1
+	dst := gaussianBlur(src, 3, EdgeTransparent)
+	total := 0.0
+	for i := 3; i < len(dst.pix); i += 4 {
+		total += dst.pix[i]
+	}
+	if math.Abs(total-1) > 1e-9 || dst.pix[center+3] >= 1 || dst.pix[center+3] <= dst.pix[center+7] {
+		t.Errorf("blurred alpha adds up to %f with peak %f, want 1 and a peak below 1", total, dst.pix[center+3])
+	}
+
+	// a zero radius copies the buffer.
+	if dst := gaussianBlur(src, 0, EdgeClamp); &dst.pix[0] == &src.pix[0] || dst.pix[center+3] != 1 {
+		t.Errorf("zero radius blur did not copy the buffer")
+	}
+}
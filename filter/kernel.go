@@ -0,0 +1,143 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/bit101/blgo"
+)
+
+// Kernel is a convolution kernel.
+type Kernel struct {
+	Width  int
+	Height int
+	// Values holds Width * Height weights, row by row.
+	Values []float64
+	// Bias is added to each channel after convolution.
+	Bias float64
+	// PreserveAlpha applies the kernel to the color channels only, leaving alpha unchanged.
+	PreserveAlpha bool
+}
+
+// NewKernel creates a new kernel with the given size and values.
+func NewKernel(width, height int, values []float64) *Kernel {
+	return &Kernel{
+		Width:  width,
+		Height: height,
+		Values: values,
+	}
+}
+
+// Normalize scales the values of the kernel so that they add up to 1.0.
+// Kernels whose values add up to zero are unchanged.
+func (k *Kernel) Normalize() *Kernel {
+	sum := 0.0
+	for _, v := range k.Values {
+		sum += v
+	}
+	if sum != 0 {
+		for i := range k.Values {
+			k.Values[i] /= sum
+		}
+	}
+	return k
+}
+
+// SharpenKernel returns a kernel that sharpens an image.
+func SharpenKernel() *Kernel {
+	return NewKernel(3, 3, []float64{
+		0, -1, 0,
+		-1, 5, -1,
+		0, -1, 0,
+	})
+}
+
+// EmbossKernel returns a kernel that embosses an image.
+func EmbossKernel() *Kernel {
+	k := NewKernel(3, 3, []float64{
+		-2, -1, 0,
+		-1, 1, 1,
+		0, 1, 2,
+	})
+	k.PreserveAlpha = true
+	return k
+}
+
+// EdgeDetectKernel returns a kernel that finds the edges in an image.
+func EdgeDetectKernel() *Kernel {
+	k := NewKernel(3, 3, []float64{
+		-1, -1, -1,
+		-1, 8, -1,
+		-1, -1, -1,
+	})
+	k.PreserveAlpha = true
+	return k
+}
+
+// Convolve applies a convolution kernel to a surface.
+// It panics if the kernel is empty or does not have Width * Height values.
+func Convolve(surface *blgo.Surface, kernel *Kernel, edge EdgeMode) {
+	if kernel.Width < 1 || kernel.Height < 1 {
+		panic(fmt.Sprintf("filter: %dx%d kernel is empty", kernel.Width, kernel.Height))
+	}
+	if len(kernel.Values) != kernel.Width*kernel.Height {
+		panic(fmt.Sprintf("filter: %dx%d kernel has %d values, want %d",
+			kernel.Width, kernel.Height, len(kernel.Values), kernel.Width*kernel.Height))
+	}
+	premultiplied := !kernel.PreserveAlpha
+	src := readBuffer(surface, premultiplied)
+	dst := convolve(src, kernel, edge)
+	writeBuffer(surface, dst, premultiplied)
+}
+
+// Sharpen sharpens a surface.
+func Sharpen(surface *blgo.Surface, edge EdgeMode) {
+	Convolve(surface, SharpenKernel(), edge)
+}
+
+// Emboss embosses a surface.
+func Emboss(surface *blgo.Surface, edge EdgeMode) {
+	Convolve(surface, EmbossKernel(), edge)
+}
+
+// EdgeDetect replaces a surface with its detected edges.
+func EdgeDetect(surface *blgo.Surface, edge EdgeMode) {
+	Convolve(surface, EdgeDetectKernel(), edge)
+}
+
+// convolve applies a kernel to a buffer, returning a new buffer.
+func convolve(src *buffer, kernel *Kernel, edge EdgeMode) *buffer {
+	dst := newBuffer(src.width, src.height)
+	cx, cy := kernel.Width/2, kernel.Height/2
+	channels := 4
+	if kernel.PreserveAlpha {
+		channels = 3
+	}
+	parallel(src.height, func(y int) {
+		for x := 0; x < src.width; x++ {
+			var sum [4]float64
+			for ky := 0; ky < kernel.Height; ky++ {
+				for kx := 0; kx < kernel.Width; kx++ {
+					weight := kernel.Values[ky*kernel.Width+kx]
+					if weight == 0 {
+						continue
+					}
+					i := src.index(x+kx-cx, y+ky-cy, edge)
+					if i < 0 {
+						continue
+					}
+					for c := 0; c < channels; c++ {
+						sum[c] += src.pix[i+c] * weight
+					}
+				}
+			}
+			i := (y*src.width + x) * 4
+			for c := 0; c < channels; c++ {
+				dst.pix[i+c] = sum[c] + kernel.Bias
+			}
+			if kernel.PreserveAlpha {
+				dst.pix[i+3] = src.pix[i+3]
+			}
+		}
+	})
+	return dst
+}
@@ -0,0 +1,207 @@
+package blgo
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/blmath"
+	"github.com/bit101/blgo/color"
+)
+
+// FloatSurface is a high dynamic range surface for additive drawing.
+// Channels are stored as premultiplied float32 r, g, b, a values with no upper limit,
+// so light can accumulate beyond 1.0 and be tone mapped down into a Surface later.
+type FloatSurface struct {
+	Width  float64
+	Height float64
+	width  int
+	height int
+	Pix    []float32
+}
+
+// NewFloatSurface creates a new, empty FloatSurface.
+func NewFloatSurface(width, height float64) *FloatSurface {
+	return &FloatSurface{
+		Width:  width,
+		Height: height,
+		width:  int(width),
+		height: int(height),
+		Pix:    make([]float32, int(width)*int(height)*4),
+	}
+}
+
+// Clear sets every channel of every pixel to zero.
+func (f *FloatSurface) Clear() {
+	for i := range f.Pix {
+		f.Pix[i] = 0
+	}
+}
+
+// Get returns the raw r, g, b, a values at a given x, y location.
+func (f *FloatSurface) Get(x, y int) (float64, float64, float64, float64) {
+	if x < 0 || x >= f.width || y < 0 || y >= f.height {
+		return 0, 0, 0, 0
+	}
+	i := (y*f.width + x) * 4
+	return float64(f.Pix[i]), float64(f.Pix[i+1]), float64(f.Pix[i+2]), float64(f.Pix[i+3])
+}
+
+// Add adds a color, scaled by amount, to the pixel at a given x, y location.
+func (f *FloatSurface) Add(x, y int, c color.Color, amount float64) {
+	if x < 0 || x >= f.width || y < 0 || y >= f.height {
+		return
+	}
+	i := (y*f.width + x) * 4
+	a := c.A * amount
+	f.Pix[i] += float32(c.R * a)
+	f.Pix[i+1] += float32(c.G * a)
+	f.Pix[i+2] += float32(c.B * a)
+	f.Pix[i+3] += float32(a)
+}
+
+// Plot adds a color at a sub pixel location, spread across the four nearest pixels.
+func (f *FloatSurface) Plot(x, y float64, c color.Color) {
+	f.plot(x, y, c, 1)
+}
+
+func (f *FloatSurface) plot(x, y float64, c color.Color, amount float64) {
+	// pixel centers are at +0.5
+	x -= 0.5
+	y -= 0.5
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	xi, yi := int(x0), int(y0)
+	f.Add(xi, yi, c, (1-fx)*(1-fy)*amount)
+	f.Add(xi+1, yi, c, fx*(1-fy)*amount)
+	f.Add(xi, yi+1, c, (1-fx)*fy*amount)
+	f.Add(xi+1, yi+1, c, fx*fy*amount)
+}
+
+// Splat adds a soft, gaussian falloff dot of light with the given radius.
+func (f *FloatSurface) Splat(x, y, radius float64, c color.Color) {
+	if radius <= 0.5 {
+		f.Plot(x, y, c)
+		return
+	}
+	sigma := radius / 3
+	x0, x1 := int(math.Floor(x-radius)), int(math.Ceil(x+radius))
+	y0, y1 := int(math.Floor(y-radius)), int(math.Ceil(y+radius))
+	for yy := y0; yy <= y1; yy++ {
+		for xx := x0; xx <= x1; xx++ {
+			dx := float64(xx) + 0.5 - x
+			dy := float64(yy) + 0.5 - y
+			distSQ := dx*dx + dy*dy
+			if distSQ > radius*radius {
+				continue
+			}
+			f.Add(xx, yy, c, math.Exp(-distSQ/(2*sigma*sigma)))
+		}
+	}
+}
+
+// Line adds a one pixel wide line of light between two x, y points.
+func (f *FloatSurface) Line(x0, y0, x1, y1 float64, c color.Color) {
+	length := math.Hypot(x1-x0, y1-y0)
+	steps := int(math.Ceil(length))
+	if steps == 0 {
+		f.Plot(x0, y0, c)
+		return
+	}
+	// each step deposits light in proportion to its length, so brightness doesn't depend on angle.
+	amount := length / float64(steps)
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		weight := amount
+		if i == 0 || i == steps {
+			weight /= 2
+		}
+		f.plot(blmath.Lerp(t, x0, x1), blmath.Lerp(t, y0, y1), c, weight)
+	}
+}
+
+// StrokeCircle adds a one pixel wide circle of light.
+func (f *FloatSurface) StrokeCircle(x, y, r float64, c color.Color) {
+	steps := int(math.Ceil(blmath.TwoPi * r))
+	if steps < 3 {
+		f.Plot(x, y, c)
+		return
+	}
+	amount := blmath.TwoPi * r / float64(steps)
+	for i := 0; i < steps; i++ {
+		angle := blmath.TwoPi * float64(i) / float64(steps)
+		f.plot(x+math.Cos(angle)*r, y+math.Sin(angle)*r, c, amount)
+	}
+}
+
+// FillCircle adds a filled, anti-aliased circle of light.
+func (f *FloatSurface) FillCircle(x, y, r float64, c color.Color) {
+	x0, x1 := int(math.Floor(x-r)), int(math.Ceil(x+r))
+	y0, y1 := int(math.Floor(y-r)), int(math.Ceil(y+r))
+	for yy := y0; yy <= y1; yy++ {
+		for xx := x0; xx <= x1; xx++ {
+			dist := math.Hypot(float64(xx)+0.5-x, float64(yy)+0.5-y)
+			coverage := blmath.Clamp(r-dist+0.5, 0, 1)
+			if coverage > 0 {
+				f.Add(xx, yy, c, coverage)
+			}
+		}
+	}
+}
+
+// ToneMapFunc maps a high dynamic range channel value to a value from 0.0 to 1.0.
+type ToneMapFunc func(value float64) float64
+
+// ToneLinear clips values above 1.0.
+func ToneLinear(value float64) float64 {
+	return blmath.Clamp(value, 0, 1)
+}
+
+// ToneReinhard compresses values with the Reinhard operator, v / (1 + v).
+func ToneReinhard(value float64) float64 {
+	value = math.Max(value, 0)
+	return value / (1 + value)
+}
+
+// ToneACES compresses values with an approximation of the ACES filmic curve.
+func ToneACES(value float64) float64 {
+	value = math.Max(value, 0)
+	return blmath.Clamp((value*(2.51*value+0.03))/(value*(2.43*value+0.59)+0.14), 0, 1)
+}
+
+// ToneMap writes the float surface into a same sized Surface, replacing its pixels.
+// Exposure is in stops, so each +1.0 doubles the brightness. Gamma of 1.0 leaves values as is.
+// Output alpha is the accumulated alpha, raised where needed to hold the mapped color.
+func (f *FloatSurface) ToneMap(surface *Surface, mapper ToneMapFunc, exposure, gamma float64) {
+	scale := math.Pow(2, exposure)
+	invGamma := 1.0
+	if gamma > 0 {
+		invGamma = 1 / gamma
+	}
+	channel := func(value float32) float64 {
+		return math.Pow(mapper(float64(value)*scale), invGamma)
+	}
+	w := int(math.Min(float64(f.width), float64(surface.GetWidth())))
+	h := int(math.Min(float64(f.height), float64(surface.GetHeight())))
+	surface.Flush()
+	data := surface.GetData()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := (y*f.width + x) * 4
+			r, g, b := channel(f.Pix[i]), channel(f.Pix[i+1]), channel(f.Pix[i+2])
+			a := math.Max(blmath.Clamp(float64(f.Pix[i+3]), 0, 1), math.Max(r, math.Max(g, b)))
+			index := surface.pixelIndex(x, y)
+			data[index] = toByte(b)
+			data[index+1] = toByte(g)
+			data[index+2] = toByte(r)
+			data[index+3] = toByte(a)
+		}
+	}
+	surface.SetData(data)
+	surface.MarkDirty()
+}
+
+// ToSurface creates a new Surface from the tone mapped float surface.
+func (f *FloatSurface) ToSurface(mapper ToneMapFunc, exposure, gamma float64) *Surface {
+	surface := NewSurface(f.Width, f.Height)
+	f.ToneMap(surface, mapper, exposure, gamma)
+	return surface
+}
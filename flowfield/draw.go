@@ -0,0 +1,32 @@
+package flowfield
+
+import (
+	"math"
+
+	"github.com/bit101/blgo"
+	"github.com/bit101/blgo/geom"
+)
+
+// Streamlines are paths of points, and can be drawn with Surface.StrokePaths,
+// or with any of the other path helpers.
+
+// FillTapered draws streamlines as filled brush strokes, tapering to points at each end.
+func FillTapered(surface *blgo.Surface, lines [][]*geom.Point, width, taper float64) {
+	for _, line := range lines {
+		surface.FillBrushFunc(line, blgo.TaperWidth(width, taper))
+	}
+}
+
+// StrokeField draws a field as short lines of a given length, spacing apart across a rectangle,
+// each pointing along the flow.
+func StrokeField(surface *blgo.Surface, field Field, bounds *geom.Rectangle, spacing, length float64) {
+	for y := bounds.Y + spacing/2; y < bounds.Y+bounds.H; y += spacing {
+		for x := bounds.X + spacing/2; x < bounds.X+bounds.W; x += spacing {
+			angle := field.Angle(x, y)
+			dx, dy := math.Cos(angle)*length/2, math.Sin(angle)*length/2
+			surface.MoveTo(x-dx, y-dy)
+			surface.LineTo(x+dx, y+dy)
+		}
+	}
+	surface.Stroke()
+}
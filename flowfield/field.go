@@ -0,0 +1,146 @@
+// Package flowfield builds vector fields from noise, attractors or functions,
+// and traces streamlines through them.
+// The noise fields pick where they sit in the noise with the random package,
+// so seeding it with random.Seed rebuilds the same fields.
+package flowfield
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/noise"
+	"github.com/bit101/blgo/random"
+)
+
+// Field returns the direction of flow at a point, as a vector.
+// Streamlines only follow its direction, but its length matters when fields are added together.
+type Field func(x, y float64) (float64, float64)
+
+// NoiseField returns a field of unit vectors, turned by perlin noise.
+// Scale sets how quickly the noise changes across the field, and turns how far it turns the flow,
+// where 1.0 turns it through a full circle.
+// Each field is cut from a different slice of the noise.
+func NoiseField(scale, turns float64) Field {
+	z := random.FloatRange(0, 256)
+	return func(x, y float64) (float64, float64) {
+		angle := noise.Perlin(x*scale, y*scale, z) * math.Pi * 2 * turns
+		return math.Cos(angle), math.Sin(angle)
+	}
+}
+
+// SimplexField returns a field of unit vectors, turned by simplex noise. See NoiseField.
+func SimplexField(scale, turns float64) Field {
+	ox, oy := random.FloatRange(0, 1000), random.FloatRange(0, 1000)
+	return func(x, y float64) (float64, float64) {
+		angle := noise.Simplex2(x*scale+ox, y*scale+oy) * math.Pi * 2 * turns
+		return math.Cos(angle), math.Sin(angle)
+	}
+}
+
+// CurlField returns the curl of perlin noise, a field that swirls without ever converging or spreading,
+// so streamlines neither bunch up nor leave gaps. Like NoiseField, it picks its slice of the noise at random.
+func CurlField(scale float64) Field {
+	z := random.FloatRange(0, 256)
+	const e = 0.001
+	return func(x, y float64) (float64, float64) {
+		x, y = x*scale, y*scale
+		dx := (noise.Perlin(x+e, y, z) - noise.Perlin(x-e, y, z)) / (2 * e)
+		dy := (noise.Perlin(x, y+e, z) - noise.Perlin(x, y-e, z)) / (2 * e)
+		return dy, -dx
+	}
+}
+
+// AttractorField returns a field flowing towards a point, with a strength falling off with distance.
+// A negative strength flows away from the point.
+func AttractorField(x, y, strength float64) Field {
+	return func(px, py float64) (float64, float64) {
+		dx, dy := x-px, y-py
+		d2 := dx*dx + dy*dy
+		if d2 == 0 {
+			return 0, 0
+		}
+		return dx * strength / d2, dy * strength / d2
+	}
+}
+
+// VortexField returns a field circling a point clockwise on screen, with a strength falling off with distance.
+// A negative strength circles anticlockwise.
+func VortexField(x, y, strength float64) Field {
+	return func(px, py float64) (float64, float64) {
+		dx, dy := px-x, py-y
+		d2 := dx*dx + dy*dy
+		if d2 == 0 {
+			return 0, 0
+		}
+		return -dy * strength / d2, dx * strength / d2
+	}
+}
+
+// Add returns a field summing the vectors of several fields.
+func Add(fields ...Field) Field {
+	return func(x, y float64) (float64, float64) {
+		vx, vy := 0.0, 0.0
+		for _, field := range fields {
+			fx, fy := field(x, y)
+			vx += fx
+			vy += fy
+		}
+		return vx, vy
+	}
+}
+
+// Angle returns the direction of a field at a point, in radians.
+func (f Field) Angle(x, y float64) float64 {
+	vx, vy := f(x, y)
+	return math.Atan2(vy, vx)
+}
+
+// Grid is a field sampled at points on a grid, which is quicker to use than a slow field
+// when tracing many streamlines.
+type Grid struct {
+	Bounds     *geom.Rectangle
+	Resolution float64
+	cols, rows int
+	vx, vy     []float64
+}
+
+// NewGrid samples a field over a rectangle, at points resolution apart.
+func NewGrid(field Field, bounds *geom.Rectangle, resolution float64) *Grid {
+	g := &Grid{
+		Bounds:     bounds,
+		Resolution: resolution,
+		cols:       int(math.Ceil(bounds.W/resolution)) + 1,
+		rows:       int(math.Ceil(bounds.H/resolution)) + 1,
+	}
+	g.vx = make([]float64, g.cols*g.rows)
+	g.vy = make([]float64, g.cols*g.rows)
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.cols; col++ {
+			i := col + row*g.cols
+			g.vx[i], g.vy[i] = field(bounds.X+float64(col)*resolution, bounds.Y+float64(row)*resolution)
+		}
+	}
+	return g
+}
+
+// At returns the field at a point, interpolated between the nearest grid points.
+// Points outside the grid take the value at its edge. Use g.At as a Field.
+func (g *Grid) At(x, y float64) (float64, float64) {
+	fx := math.Max(0, math.Min((x-g.Bounds.X)/g.Resolution, float64(g.cols-1)))
+	fy := math.Max(0, math.Min((y-g.Bounds.Y)/g.Resolution, float64(g.rows-1)))
+	col := int(math.Min(fx, float64(g.cols-2)))
+	row := int(math.Min(fy, float64(g.rows-2)))
+	if col < 0 || row < 0 {
+		// a grid a single point wide or high.
+		i := int(fx) + int(fy)*g.cols
+		return g.vx[i], g.vy[i]
+	}
+	tx, ty := fx-float64(col), fy-float64(row)
+	i := col + row*g.cols
+	sample := func(v []float64) float64 {
+		top := v[i] + (v[i+1]-v[i])*tx
+		bottom := v[i+g.cols] + (v[i+g.cols+1]-v[i+g.cols])*tx
+		return top + (bottom-top)*ty
+	}
+	return sample(g.vx), sample(g.vy)
+}
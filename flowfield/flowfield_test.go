@@ -0,0 +1,83 @@
+package flowfield
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/random"
+)
+
+func TestTraceCircle(t *testing.T) {
+	// a vortex traced with RK4 should stay on its circle.
+	tracer := NewTracer(VortexField(50, 50, 1), geom.NewRectangle(0, 0, 100, 100))
+	tracer.MaxSteps = 100
+	line := tracer.Trace(80, 50)
+	if len(line) != 201 {
+		t.Fatalf("traced %d points, want 201", len(line))
+	}
+	for _, p := range line {
+		if d := math.Hypot(p.X-50, p.Y-50); math.Abs(d-30) > 1e-3 {
+			t.Fatalf("point %v is %f from the center, want 30", p, d)
+		}
+	}
+	// clockwise on screen, so moving down from the right hand side.
+	if seed, next := line[100], line[101]; next.Y <= seed.Y {
+		t.Errorf("vortex turns from %v to %v, want downwards", seed, next)
+	}
+}
+
+func TestTraceStops(t *testing.T) {
+	bounds := geom.NewRectangle(0, 0, 100, 100)
+	line := NewTracer(AttractorField(50, 50, 1), bounds).Trace(20, 50)
+	last := line[len(line)-1]
+	if last.Distance(geom.NewPoint(50, 50)) > 1 {
+		t.Errorf("line toward attractor ends at %v", last)
+	}
+	if first := line[0]; first.X > 1 {
+		t.Errorf("line away from attractor starts at %v, want at the edge", first)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	field := func(x, y float64) (float64, float64) {
+		return x, 2 * y
+	}
+	grid := NewGrid(field, geom.NewRectangle(0, 0, 10, 10), 3)
+	x, y := grid.At(4.5, 7)
+	if math.Abs(x-4.5) > 1e-9 || math.Abs(y-14) > 1e-9 {
+		t.Errorf("grid gave %f, %f, want 4.5, 14", x, y)
+	}
+	x, _ = grid.At(-5, 0)
+	if x != 0 {
+		t.Errorf("grid outside its bounds gave %f, want 0", x)
+	}
+}
+
+func TestEvenlySpaced(t *testing.T) {
+	random.Seed(1)
+	bounds := geom.NewRectangle(0, 0, 200, 200)
+	tracer := NewTracer(CurlField(0.01), bounds)
+	lines := tracer.EvenlySpaced()
+	if len(lines) < 10 {
+		t.Fatalf("got %d lines", len(lines))
+	}
+	test := tracer.Separation * tracer.TestRatio
+	for i, a := range lines {
+		for _, b := range lines[i+1:] {
+			for _, p := range a {
+				for _, q := range b {
+					if p.Distance(q) < test-1e-9 {
+						t.Fatalf("lines come %f apart, want at least %f", p.Distance(q), test)
+					}
+				}
+			}
+		}
+	}
+
+	random.Seed(1)
+	again := NewTracer(CurlField(0.01), bounds).EvenlySpaced()
+	if len(again) != len(lines) || again[3][0].X != lines[3][0].X {
+		t.Errorf("seeding again gave different lines")
+	}
+}
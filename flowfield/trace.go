@@ -0,0 +1,239 @@
+package flowfield
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+)
+
+// Tracer traces streamlines through a field, within a rectangle.
+type Tracer struct {
+	Field  Field
+	Bounds *geom.Rectangle
+	// Step is the distance between points on a streamline.
+	Step float64
+	// MaxSteps is the most points a streamline can have in each direction from its seed.
+	MaxSteps int
+	// Separation is how far apart EvenlySpaced starts streamlines,
+	// and TestRatio the fraction of it that streamlines may come closer than before they stop.
+	Separation float64
+	TestRatio  float64
+}
+
+// NewTracer creates a new tracer for a field within a rectangle.
+func NewTracer(field Field, bounds *geom.Rectangle) *Tracer {
+	return &Tracer{
+		Field:      field,
+		Bounds:     bounds,
+		Step:       1,
+		MaxSteps:   1000,
+		Separation: 10,
+		TestRatio:  0.5,
+	}
+}
+
+// direction returns the unit vector of the field at a point, and false where the field has no direction.
+func (t *Tracer) direction(x, y float64) (float64, float64, bool) {
+	vx, vy := t.Field(x, y)
+	length := math.Hypot(vx, vy)
+	if length < 1e-12 || math.IsNaN(length) {
+		return 0, 0, false
+	}
+	return vx / length, vy / length, true
+}
+
+// advance takes a fourth order Runge-Kutta step of length h along the field.
+func (t *Tracer) advance(p *geom.Point, h float64) (*geom.Point, bool) {
+	x1, y1, ok1 := t.direction(p.X, p.Y)
+	x2, y2, ok2 := t.direction(p.X+x1*h/2, p.Y+y1*h/2)
+	x3, y3, ok3 := t.direction(p.X+x2*h/2, p.Y+y2*h/2)
+	x4, y4, ok4 := t.direction(p.X+x3*h, p.Y+y3*h)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil, false
+	}
+	return geom.NewPoint(
+		p.X+h/6*(x1+2*x2+2*x3+x4),
+		p.Y+h/6*(y1+2*y2+2*y3+y4),
+	), true
+}
+
+// Trace returns the streamline through a point, traced both ways until it leaves the bounds,
+// reaches a point with no flow, or runs MaxSteps.
+func (t *Tracer) Trace(x, y float64) []*geom.Point {
+	return t.trace(geom.NewPoint(x, y), nil)
+}
+
+// TraceFrom returns the streamlines through each of a set of seed points, such as geom.PoissonDisk gives.
+func (t *Tracer) TraceFrom(seeds []*geom.Point) [][]*geom.Point {
+	var lines [][]*geom.Point
+	for _, seed := range seeds {
+		if line := t.Trace(seed.X, seed.Y); len(line) > 1 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// trace traces a streamline both ways from a seed. If stop is not nil, tracing each way ends
+// at the first point it rejects, given the index of the point along that way.
+func (t *Tracer) trace(seed *geom.Point, stop func(p *geom.Point, i int) bool) []*geom.Point {
+	if t.Step <= 0 || !t.inBounds(seed) {
+		return nil
+	}
+	var halves [2][]*geom.Point
+	for k, h := range []float64{-t.Step, t.Step} {
+		p := seed
+		for i := 1; i <= t.MaxSteps; i++ {
+			next, ok := t.advance(p, h)
+			if !ok || !t.inBounds(next) || (stop != nil && stop(next, i*int(h/t.Step))) {
+				break
+			}
+			halves[k] = append(halves[k], next)
+			p = next
+		}
+	}
+	back := halves[0]
+	line := make([]*geom.Point, 0, len(back)+len(halves[1])+1)
+	for i := len(back) - 1; i >= 0; i-- {
+		line = append(line, back[i])
+	}
+	line = append(line, seed)
+	return append(line, halves[1]...)
+}
+
+func (t *Tracer) inBounds(p *geom.Point) bool {
+	return geom.PointInRect(p, t.Bounds.X, t.Bounds.Y, t.Bounds.W, t.Bounds.H)
+}
+
+// linePoint is a point of a streamline, as stored in the grid EvenlySpaced uses to find nearby lines.
+type linePoint struct {
+	point *geom.Point
+	line  int
+	index int
+}
+
+// EvenlySpaced fills the bounds with streamlines about Separation apart, using the Jobard-Lefer algorithm.
+// New streamlines start Separation to either side of existing ones, and stop on coming closer than
+// Separation * TestRatio to any other, or looping back near themselves.
+func (t *Tracer) EvenlySpaced() [][]*geom.Point {
+	if t.Separation <= 0 {
+		return nil
+	}
+	test := t.Separation * t.TestRatio
+	cell := t.Separation
+	cols := int(math.Ceil(t.Bounds.W/cell)) + 1
+	rows := int(math.Ceil(t.Bounds.H/cell)) + 1
+	grid := make([][]linePoint, cols*rows)
+	cellOf := func(p *geom.Point) (int, int) {
+		return int((p.X - t.Bounds.X) / cell), int((p.Y - t.Bounds.Y) / cell)
+	}
+	// near returns whether a point is closer than dist to any streamline, ignoring the points of
+	// its own line close along it.
+	near := func(p *geom.Point, dist float64, line, index int) bool {
+		col, row := cellOf(p)
+		reach := int(math.Ceil(dist / cell))
+		own := int(math.Ceil(t.Separation*2/t.Step)) + 1
+		for y := row - reach; y <= row+reach; y++ {
+			for x := col - reach; x <= col+reach; x++ {
+				if x < 0 || x >= cols || y < 0 || y >= rows {
+					continue
+				}
+				for _, lp := range grid[x+y*cols] {
+					if lp.line == line && abs(lp.index-index) < own {
+						continue
+					}
+					if lp.point.Distance(p) < dist {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+	add := func(lp linePoint) {
+		col, row := cellOf(lp.point)
+		grid[col+row*cols] = append(grid[col+row*cols], lp)
+	}
+
+	var lines [][]*geom.Point
+	// traceAt traces and keeps a new streamline from a seed far enough from the others.
+	traceAt := func(seed *geom.Point) {
+		if !t.inBounds(seed) || near(seed, t.Separation, -1, 0) {
+			return
+		}
+		id := len(lines)
+		// points are added to the grid as each half is traced, so the line can find loops back to itself.
+		var traced []linePoint
+		line := t.trace(seed, func(p *geom.Point, i int) bool {
+			if near(p, test, id, i) {
+				return true
+			}
+			add(linePoint{p, id, i})
+			traced = append(traced, linePoint{p, id, i})
+			return false
+		})
+		if len(line) < 2 {
+			// drop the points of lines too short to keep.
+			for _, lp := range traced {
+				col, row := cellOf(lp.point)
+				points := grid[col+row*cols]
+				for k := range points {
+					if points[k].line == id {
+						points = append(points[:k], points[k+1:]...)
+						break
+					}
+				}
+				grid[col+row*cols] = points
+			}
+			return
+		}
+		add(linePoint{seed, id, 0})
+		lines = append(lines, line)
+	}
+
+	// seed from the middle, then spread out from each line in turn, then fill any gaps left.
+	traceAt(geom.NewPoint(t.Bounds.X+t.Bounds.W/2, t.Bounds.Y+t.Bounds.H/2))
+	for done := 0; ; {
+		for ; done < len(lines); done++ {
+			line := lines[done]
+			for i, p := range line {
+				dx, dy := segmentDirection(line, i)
+				traceAt(geom.NewPoint(p.X-dy*t.Separation, p.Y+dx*t.Separation))
+				traceAt(geom.NewPoint(p.X+dy*t.Separation, p.Y-dx*t.Separation))
+			}
+		}
+		before := len(lines)
+		for y := t.Bounds.Y + cell/2; y < t.Bounds.Y+t.Bounds.H && len(lines) == before; y += cell {
+			for x := t.Bounds.X + cell/2; x < t.Bounds.X+t.Bounds.W && len(lines) == before; x += cell {
+				traceAt(geom.NewPoint(x, y))
+			}
+		}
+		if len(lines) == before {
+			return lines
+		}
+	}
+}
+
+// segmentDirection returns the unit direction of a path at one of its points.
+func segmentDirection(line []*geom.Point, i int) (float64, float64) {
+	a, b := i-1, i+1
+	if a < 0 {
+		a = 0
+	}
+	if b >= len(line) {
+		b = len(line) - 1
+	}
+	dx, dy := line[b].X-line[a].X, line[b].Y-line[a].Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return 0, 0
+	}
+	return dx / length, dy / length
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
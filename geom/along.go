@@ -0,0 +1,110 @@
+package geom
+
+import "math"
+
+// PathSample is a point along a path, with the angle of the path at that point.
+type PathSample struct {
+	Point *Point
+	Angle float64
+}
+
+// PointAtDistance returns the point a distance along a path, and the angle of the path there.
+// Distances beyond either end of the path are clamped to it.
+func PointAtDistance(points []*Point, distance float64) PathSample {
+	if len(points) == 1 {
+		return PathSample{NewPoint(points[0].X, points[0].Y), 0}
+	}
+	for i := 1; i < len(points); i++ {
+		p0, p1 := points[i-1], points[i]
+		length := p0.Distance(p1)
+		if distance <= length || i == len(points)-1 {
+			t := 0.0
+			if length > 0 {
+				t = math.Max(0, math.Min(1, distance/length))
+			}
+			return PathSample{LerpPoint(t, p0, p1), math.Atan2(p1.Y-p0.Y, p1.X-p0.X)}
+		}
+		distance -= length
+	}
+	return PathSample{}
+}
+
+// SamplePath returns points spaced evenly along a path, starting offset from its start,
+// each with the angle of the path there. Angles at corners are those of the segment that follows.
+func SamplePath(points []*Point, spacing, offset float64) []PathSample {
+	if len(points) < 2 || spacing <= 0 {
+		return nil
+	}
+	var samples []PathSample
+	d := math.Mod(offset, spacing)
+	if d < 0 {
+		d += spacing
+	}
+	travelled := 0.0
+	for i := 1; i < len(points); i++ {
+		p0, p1 := points[i-1], points[i]
+		length := p0.Distance(p1)
+		if length == 0 {
+			continue
+		}
+		angle := math.Atan2(p1.Y-p0.Y, p1.X-p0.X)
+		end := travelled + length
+		if i == len(points)-1 {
+			end += 1e-9
+		}
+		for ; d < end; d += spacing {
+			t := math.Min(1, (d-travelled)/length)
+			samples = append(samples, PathSample{LerpPoint(t, p0, p1), angle})
+		}
+		travelled += length
+	}
+	return samples
+}
+
+// PathSection returns the part of a path between two distances along it.
+func PathSection(points []*Point, from, to float64) []*Point {
+	if len(points) == 0 || to < from {
+		return nil
+	}
+	section := []*Point{PointAtDistance(points, from).Point}
+	travelled := 0.0
+	for i := 1; i < len(points); i++ {
+		travelled += points[i-1].Distance(points[i])
+		if travelled > from && travelled < to {
+			section = append(section, points[i])
+		}
+	}
+	return append(section, PointAtDistance(points, to).Point)
+}
+
+// Dash splits a path into dashes, following a pattern of alternating dash and gap lengths,
+// which starts offset along the path. As with cairo, a pattern with an odd number of lengths
+// swaps between dashes and gaps each time through. Dashes of zero length are returned as a pair of equal points,
+// which draw as dots with round line caps.
+func Dash(points []*Point, pattern []float64, offset float64) [][]*Point {
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float64{}, pattern...), pattern...)
+	}
+	cycle := 0.0
+	for _, length := range pattern {
+		cycle += length
+	}
+	if len(points) < 2 || cycle <= 0 {
+		return [][]*Point{points}
+	}
+	total := PathLength(points)
+	// start far enough back that the pattern is in step at the offset.
+	d := -math.Mod(offset, cycle)
+	if d > 0 {
+		d -= cycle
+	}
+	var dashes [][]*Point
+	for i := 0; d < total; i = (i + 1) % len(pattern) {
+		end := d + pattern[i]
+		if i%2 == 0 && end >= 0 {
+			dashes = append(dashes, PathSection(points, math.Max(d, 0), math.Min(end, total)))
+		}
+		d = end
+	}
+	return dashes
+}
@@ -0,0 +1,87 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func elbow() []*Point {
+	return []*Point{NewPoint(0, 0), NewPoint(10, 0), NewPoint(10, 10)}
+}
+
+func TestPointAtDistance(t *testing.T) {
+	var tests = []struct {
+		distance float64
+		x, y     float64
+		angle    float64
+	}{
+		{0, 0, 0, 0},
+		{5, 5, 0, 0},
+		{15, 10, 5, math.Pi / 2},
+		{25, 10, 10, math.Pi / 2},
+		{-5, 0, 0, 0},
+	}
+	for _, test := range tests {
+		sample := PointAtDistance(elbow(), test.distance)
+		if sample.Point.Distance(NewPoint(test.x, test.y)) > 1e-9 || math.Abs(sample.Angle-test.angle) > 1e-9 {
+			t.Errorf("point at %f is %v at %f, want %f, %f at %f",
+				test.distance, sample.Point, sample.Angle, test.x, test.y, test.angle)
+		}
+	}
+}
+
+func TestSamplePath(t *testing.T) {
+	samples := SamplePath(elbow(), 5, 0)
+	if len(samples) != 5 {
+		t.Fatalf("got %d samples, want 5", len(samples))
+	}
+	if samples[2].Point.Distance(NewPoint(10, 0)) > 1e-9 || samples[2].Angle != math.Pi/2 {
+		t.Errorf("corner sample is %v at %f", samples[2].Point, samples[2].Angle)
+	}
+	samples = SamplePath(elbow(), 5, 2)
+	if len(samples) != 4 || samples[0].Point.Distance(NewPoint(2, 0)) > 1e-9 {
+		t.Errorf("offset samples start at %v, want 2, 0", samples[0].Point)
+	}
+}
+
+func TestPathSection(t *testing.T) {
+	section := PathSection(elbow(), 5, 15)
+	want := []*Point{NewPoint(5, 0), NewPoint(10, 0), NewPoint(10, 5)}
+	if len(section) != len(want) {
+		t.Fatalf("section has %d points, want %d", len(section), len(want))
+	}
+	for i, p := range want {
+		if section[i].Distance(p) > 1e-9 {
+			t.Errorf("section point %d is %v, want %v", i, section[i], p)
+		}
+	}
+}
+
+func TestDash(t *testing.T) {
+	dashes := Dash(elbow(), []float64{4, 2}, 0)
+	// dashes at 0-4, 6-10, 12-16 and 18-20.
+	if len(dashes) != 4 {
+		t.Fatalf("got %d dashes, want 4", len(dashes))
+	}
+	length := 0.0
+	for _, dash := range dashes {
+		length += PathLength(dash)
+	}
+	if math.Abs(length-14) > 1e-9 {
+		t.Errorf("dashes have length %f, want 14", length)
+	}
+	if dashes := Dash(elbow(), []float64{6, 2}, 0); len(dashes[1]) != 3 {
+		t.Errorf("dash around corner has %d points, want 3", len(dashes[1]))
+	}
+
+	// an offset moves the pattern back along the path.
+	dashes = Dash(elbow(), []float64{4, 2}, 3)
+	if PathLength(dashes[0]) != 1 {
+		t.Errorf("first offset dash has length %f, want 1", PathLength(dashes[0]))
+	}
+
+	// odd patterns swap dashes and gaps.
+	if dashes := Dash(elbow(), []float64{5}, 0); len(dashes) != 2 {
+		t.Errorf("odd pattern has %d dashes, want 2", len(dashes))
+	}
+}
@@ -0,0 +1,442 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// FlattenBezier returns points along a cubic bezier curve, including both end points,
+// with no part of the curve further than tolerance from the straight lines between them.
+func FlattenBezier(p0, p1, p2, p3 *Point, tolerance float64) []*Point {
+	if tolerance <= 0 {
+		tolerance = 0.1
+	}
+	// the control polygon bounds how far the curve can deviate from its chord,
+	// which gives a safe number of segments for the tolerance.
+	dd := 0.0
+	for _, p := range [][3]*Point{{p0, p1, p2}, {p1, p2, p3}} {
+		dx := p[0].X - 2*p[1].X + p[2].X
+		dy := p[0].Y - 2*p[1].Y + p[2].Y
+		if d := dx*dx + dy*dy; d > dd {
+			dd = d
+		}
+	}
+	n := 1
+	if dd > 0 {
+		n = int(math.Ceil(math.Sqrt(0.75 * math.Sqrt(dd) / tolerance)))
+	}
+	if n < 1 {
+		n = 1
+	}
+	points := make([]*Point, n+1)
+	for i := 0; i <= n; i++ {
+		points[i] = BezierPoint(p0, p1, p2, p3, float64(i)/float64(n))
+	}
+	return points
+}
+
+// FlattenBezierPath returns points along a path of joined cubic bezier curves.
+// The path is given as a start point followed by two control points and an end point for each curve,
+// and each curve starts where the last one ended.
+func FlattenBezierPath(points []*Point, tolerance float64) []*Point {
+	if len(points) < 4 {
+		return points
+	}
+	result := []*Point{points[0]}
+	for i := 0; i+3 < len(points); i += 3 {
+		result = append(result, FlattenBezier(points[i], points[i+1], points[i+2], points[i+3], tolerance)[1:]...)
+	}
+	return result
+}
+
+// BezierDerivative returns the derivative of a cubic bezier curve at t, as a vector.
+// It points along the curve, and its length is how fast the curve moves at t.
+func BezierDerivative(p0, p1, p2, p3 *Point, t float64) *Point {
+	m0 := 3 * (1 - t) * (1 - t)
+	m1 := 6 * (1 - t) * t
+	m2 := 3 * t * t
+	return NewPoint(
+		m0*(p1.X-p0.X)+m1*(p2.X-p1.X)+m2*(p3.X-p2.X),
+		m0*(p1.Y-p0.Y)+m1*(p2.Y-p1.Y)+m2*(p3.Y-p2.Y),
+	)
+}
+
+// BezierPathPoint returns the point at t along a path of joined cubic bezier curves, as FlattenBezierPath takes.
+// Each curve takes an equal share of t, from 0.0 at the start of the path to 1.0 at its end.
+// A path with no curves gives its start point, or nil if it is empty.
+func BezierPathPoint(path []*Point, t float64) *Point {
+	i, u := bezierPathSegment(path, t)
+	if i < 0 {
+		if len(path) == 0 {
+			return nil
+		}
+		return path[0]
+	}
+	return BezierPoint(path[i], path[i+1], path[i+2], path[i+3], u)
+}
+
+// BezierPathTangent returns the derivative at t along a path of joined cubic bezier curves, as a vector.
+func BezierPathTangent(path []*Point, t float64) *Point {
+	i, u := bezierPathSegment(path, t)
+	if i < 0 {
+		return NewPoint(0, 0)
+	}
+	d := BezierDerivative(path[i], path[i+1], path[i+2], path[i+3], u)
+	segments := float64((len(path) - 1) / 3)
+	return NewPoint(d.X*segments, d.Y*segments)
+}
+
+// bezierPathSegment returns the index of the first point of the curve at t along a bezier path,
+// and how far along that curve t is. The index is -1 if the path has no curves.
+func bezierPathSegment(path []*Point, t float64) (int, float64) {
+	segments := (len(path) - 1) / 3
+	if segments < 1 {
+		return -1, 0
+	}
+	t = math.Max(0, math.Min(1, t)) * float64(segments)
+	i := int(math.Min(math.Floor(t), float64(segments-1)))
+	return i * 3, t - float64(i)
+}
+
+// BezierTangent returns the unit vector pointing along a cubic bezier curve at t.
+func BezierTangent(p0, p1, p2, p3 *Point, t float64) *Point {
+	d := BezierDerivative(p0, p1, p2, p3, t)
+	length := math.Hypot(d.X, d.Y)
+	if length < 1e-12 {
+		// where control points sit on the end points, the derivative vanishes.
+		// step in a little to find the direction.
+		if t < 0.5 {
+			d = BezierDerivative(p0, p1, p2, p3, t+1e-4)
+		} else {
+			d = BezierDerivative(p0, p1, p2, p3, t-1e-4)
+		}
+		length = math.Hypot(d.X, d.Y)
+		if length < 1e-12 {
+			return NewPoint(0, 0)
+		}
+	}
+	return NewPoint(d.X/length, d.Y/length)
+}
+
+// BezierNormal returns the unit vector at right angles to a cubic bezier curve at t,
+// pointing to the left of the curve as seen on screen.
+func BezierNormal(p0, p1, p2, p3 *Point, t float64) *Point {
+	tangent := BezierTangent(p0, p1, p2, p3, t)
+	return NewPoint(tangent.Y, -tangent.X)
+}
+
+// gauss-legendre nodes and weights, for integrating over -1 to 1.
+var (
+	legendreNodes   = [5]float64{0, -0.5384693101056831, 0.5384693101056831, -0.9061798459386640, 0.9061798459386640}
+	legendreWeights = [5]float64{0.5688888888888889, 0.4786286704993665, 0.4786286704993665, 0.2369268850561891, 0.2369268850561891}
+)
+
+// BezierLength returns the length of a cubic bezier curve.
+func BezierLength(p0, p1, p2, p3 *Point) float64 {
+	return bezierLengthTo(p0, p1, p2, p3, 1)
+}
+
+// bezierLengthTo returns the length of a cubic bezier curve from its start to t.
+func bezierLengthTo(p0, p1, p2, p3 *Point, t float64) float64 {
+	const steps = 8
+	length := 0.0
+	h := t / steps
+	for i := 0; i < steps; i++ {
+		mid := h * (float64(i) + 0.5)
+		for j, x := range legendreNodes {
+			d := BezierDerivative(p0, p1, p2, p3, mid+x*h/2)
+			length += legendreWeights[j] * math.Hypot(d.X, d.Y) * h / 2
+		}
+	}
+	return length
+}
+
+// BezierParamAtLength returns the t at a distance along a cubic bezier curve.
+// Stepping the distance evenly moves evenly along the curve, where stepping t does not.
+func BezierParamAtLength(p0, p1, p2, p3 *Point, length float64) float64 {
+	total := BezierLength(p0, p1, p2, p3)
+	if length <= 0 || total == 0 {
+		return 0
+	}
+	if length >= total {
+		return 1
+	}
+	// newton's method from a linear guess, kept within a shrinking bracket.
+	lo, hi := 0.0, 1.0
+	t := length / total
+	for i := 0; i < 50; i++ {
+		diff := bezierLengthTo(p0, p1, p2, p3, t) - length
+		if math.Abs(diff) < 1e-9*total {
+			break
+		}
+		if diff > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+		d := BezierDerivative(p0, p1, p2, p3, t)
+		next := t - diff/math.Hypot(d.X, d.Y)
+		if math.IsNaN(next) || next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+		t = next
+	}
+	return t
+}
+
+// BezierPathParamAtLength returns the t at a distance along a path of joined cubic bezier curves,
+// as BezierPathPoint and BezierPathTangent take.
+func BezierPathParamAtLength(path []*Point, length float64) float64 {
+	segments := (len(path) - 1) / 3
+	for i := 0; i < segments; i++ {
+		p := path[i*3 : i*3+4]
+		segment := BezierLength(p[0], p[1], p[2], p[3])
+		if length <= segment || i == segments-1 {
+			return (float64(i) + BezierParamAtLength(p[0], p[1], p[2], p[3], length)) / float64(segments)
+		}
+		length -= segment
+	}
+	return 0
+}
+
+// SplitBezier splits a cubic bezier curve in two at t, returning the four points of each part.
+func SplitBezier(p0, p1, p2, p3 *Point, t float64) ([]*Point, []*Point) {
+	a := LerpPoint(t, p0, p1)
+	b := LerpPoint(t, p1, p2)
+	c := LerpPoint(t, p2, p3)
+	d := LerpPoint(t, a, b)
+	e := LerpPoint(t, b, c)
+	f := LerpPoint(t, d, e)
+	return []*Point{p0, a, d, f}, []*Point{f, e, c, p3}
+}
+
+// BezierBounds returns the smallest rectangle containing a cubic bezier curve.
+func BezierBounds(p0, p1, p2, p3 *Point) *Rectangle {
+	points := []*Point{p0, p3}
+	// the curve turns back on itself in x or y where the derivative in that axis is zero.
+	for _, axis := range [][4]float64{{p0.X, p1.X, p2.X, p3.X}, {p0.Y, p1.Y, p2.Y, p3.Y}} {
+		a := -axis[0] + 3*axis[1] - 3*axis[2] + axis[3]
+		b := 2 * (axis[0] - 2*axis[1] + axis[2])
+		c := axis[1] - axis[0]
+		for _, t := range solveQuadratic(a, b, c) {
+			if t > 0 && t < 1 {
+				points = append(points, BezierPoint(p0, p1, p2, p3, t))
+			}
+		}
+	}
+	return BoundingBox(points)
+}
+
+// NearestPointOnBezier returns the point on a cubic bezier curve nearest to another point, and its t.
+func NearestPointOnBezier(p, p0, p1, p2, p3 *Point) (*Point, float64) {
+	const samples = 64
+	distance := func(t float64) float64 {
+		q := BezierPoint(p0, p1, p2, p3, t)
+		return (q.X-p.X)*(q.X-p.X) + (q.Y-p.Y)*(q.Y-p.Y)
+	}
+	best, bestDistance := 0.0, math.MaxFloat64
+	for i := 0; i <= samples; i++ {
+		t := float64(i) / samples
+		if d := distance(t); d < bestDistance {
+			best, bestDistance = t, d
+		}
+	}
+	// the nearest sample is within a sample's width of the true nearest point. narrow it down.
+	lo, hi := math.Max(0, best-1.0/samples), math.Min(1, best+1.0/samples)
+	for i := 0; i < 60; i++ {
+		m0, m1 := lo+(hi-lo)/3, hi-(hi-lo)/3
+		if distance(m0) < distance(m1) {
+			hi = m1
+		} else {
+			lo = m0
+		}
+	}
+	t := (lo + hi) / 2
+	return BezierPoint(p0, p1, p2, p3, t), t
+}
+
+// BezierLineIntersections returns the points where a cubic bezier curve crosses a line segment.
+func BezierLineIntersections(p0, p1, p2, p3, l0, l1 *Point) []*Point {
+	dx, dy := l1.X-l0.X, l1.Y-l0.Y
+	lengthSQ := dx*dx + dy*dy
+	if lengthSQ == 0 {
+		return nil
+	}
+	// the curve crosses the line where its distance along the line's normal is zero.
+	// written as a cubic in t, that is a*t^3 + b*t^2 + c*t + d = 0.
+	nx, ny := -dy, dx
+	dot := func(x, y float64) float64 { return nx*x + ny*y }
+	a := dot(-p0.X+3*p1.X-3*p2.X+p3.X, -p0.Y+3*p1.Y-3*p2.Y+p3.Y)
+	b := dot(3*p0.X-6*p1.X+3*p2.X, 3*p0.Y-6*p1.Y+3*p2.Y)
+	c := dot(-3*p0.X+3*p1.X, -3*p0.Y+3*p1.Y)
+	d := dot(p0.X-l0.X, p0.Y-l0.Y)
+	var points []*Point
+	for _, t := range solveCubic(a, b, c, d) {
+		if t < -1e-9 || t > 1+1e-9 {
+			continue
+		}
+		p := BezierPoint(p0, p1, p2, p3, math.Max(0, math.Min(1, t)))
+		u := ((p.X-l0.X)*dx + (p.Y-l0.Y)*dy) / lengthSQ
+		if u >= -1e-9 && u <= 1+1e-9 {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// BezierIntersections returns the points where two cubic bezier curves cross.
+// Where the curves lie along each other, it returns the two ends of the stretch they share.
+func BezierIntersections(a0, a1, a2, a3, b0, b1, b2, b3 *Point) []*Point {
+	bounds := BoundingBox([]*Point{a0, a1, a2, a3, b0, b1, b2, b3})
+	tolerance := math.Max(bounds.W, bounds.H) * 1e-9
+	// overlapping curves touch everywhere along the overlap, which splitting would never finish finding.
+	if overlap := bezierOverlap([]*Point{a0, a1, a2, a3}, []*Point{b0, b1, b2, b3}, tolerance*100); overlap != nil {
+		return overlap
+	}
+	var points []*Point
+	add := func(p *Point) {
+		for _, q := range points {
+			if q.Distance(p) < tolerance*1e3 {
+				return
+			}
+		}
+		points = append(points, p)
+	}
+	// split the curves until the pieces that might touch are flat enough to treat as lines.
+	var find func(a, b []*Point, depth int)
+	find = func(a, b []*Point, depth int) {
+		if !newBox(a...).overlaps(newBox(b...)) {
+			return
+		}
+		flatA, flatB := bezierFlat(a, tolerance), bezierFlat(b, tolerance)
+		if (flatA && flatB) || depth > 50 {
+			s0 := &splitEdge{p0: a[0], p1: a[3]}
+			s1 := &splitEdge{p0: b[0], p1: b[3]}
+			if p := segmentCrossing(s0, s1); p != nil {
+				add(p)
+			}
+			return
+		}
+		if flatB || (!flatA && a[0].Distance(a[3]) >= b[0].Distance(b[3])) {
+			left, right := SplitBezier(a[0], a[1], a[2], a[3], 0.5)
+			find(left, b, depth+1)
+			find(right, b, depth+1)
+			return
+		}
+		left, right := SplitBezier(b[0], b[1], b[2], b[3], 0.5)
+		find(a, left, depth+1)
+		find(a, right, depth+1)
+	}
+	find([]*Point{a0, a1, a2, a3}, []*Point{b0, b1, b2, b3}, 0)
+	return points
+}
+
+// bezierOverlap returns the ends of the stretch two curves share, or nil if they don't lie along each other.
+// Curves can only overlap along the same underlying cubic, so the overlap runs between ends of the curves
+// that lie on the other curve.
+func bezierOverlap(a, b []*Point, tolerance float64) []*Point {
+	var params []float64
+	onCurve := func(p *Point, c []*Point) (float64, bool) {
+		q, t := NearestPointOnBezier(p, c[0], c[1], c[2], c[3])
+		return t, q.Distance(p) <= tolerance
+	}
+	for i, p := range []*Point{a[0], a[3]} {
+		if _, ok := onCurve(p, b); ok {
+			params = append(params, float64(i))
+		}
+	}
+	for _, p := range []*Point{b[0], b[3]} {
+		if t, ok := onCurve(p, a); ok {
+			params = append(params, t)
+		}
+	}
+	if len(params) < 2 {
+		return nil
+	}
+	sort.Float64s(params)
+	t0, t1 := params[0], params[len(params)-1]
+	start := BezierPoint(a[0], a[1], a[2], a[3], t0)
+	end := BezierPoint(a[0], a[1], a[2], a[3], t1)
+	if start.Distance(end) <= tolerance {
+		return nil
+	}
+	// curves that only touch at their ends part in between.
+	for i := 1; i < 8; i++ {
+		p := BezierPoint(a[0], a[1], a[2], a[3], t0+(t1-t0)*float64(i)/8)
+		if _, ok := onCurve(p, b); !ok {
+			return nil
+		}
+	}
+	return []*Point{start, end}
+}
+
+// bezierFlat returns whether a cubic bezier curve's control points are within tolerance of its chord.
+func bezierFlat(p []*Point, tolerance float64) bool {
+	return DistanceToSegment(p[1], p[0], p[3]) <= tolerance && DistanceToSegment(p[2], p[0], p[3]) <= tolerance
+}
+
+// segmentCrossing returns where two line segments cross, including at their end points, or nil.
+func segmentCrossing(a, b *splitEdge) *Point {
+	d1x, d1y := a.p1.X-a.p0.X, a.p1.Y-a.p0.Y
+	d2x, d2y := b.p1.X-b.p0.X, b.p1.Y-b.p0.Y
+	denom := d1x*d2y - d1y*d2x
+	if denom == 0 {
+		return nil
+	}
+	ex, ey := b.p0.X-a.p0.X, b.p0.Y-a.p0.Y
+	t := (ex*d2y - ey*d2x) / denom
+	u := (ex*d1y - ey*d1x) / denom
+	const slack = 1e-9
+	if t < -slack || t > 1+slack || u < -slack || u > 1+slack {
+		return nil
+	}
+	return NewPoint(a.p0.X+d1x*t, a.p0.Y+d1y*t)
+}
+
+// solveQuadratic returns the real roots of a*x^2 + b*x + c = 0.
+func solveQuadratic(a, b, c float64) []float64 {
+	if math.Abs(a) <= 1e-12*math.Max(math.Abs(b), math.Abs(c)) {
+		if b == 0 {
+			return nil
+		}
+		return []float64{-c / b}
+	}
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return nil
+	}
+	// avoid cancellation by finding the larger root first.
+	q := -(b + math.Copysign(math.Sqrt(disc), b)) / 2
+	if q == 0 {
+		return []float64{0}
+	}
+	return []float64{q / a, c / q}
+}
+
+// solveCubic returns the real roots of a*x^3 + b*x^2 + c*x + d = 0.
+func solveCubic(a, b, c, d float64) []float64 {
+	if math.Abs(a) <= 1e-12*math.Max(math.Max(math.Abs(b), math.Abs(c)), math.Abs(d)) {
+		return solveQuadratic(b, c, d)
+	}
+	b, c, d = b/a, c/a, d/a
+	// substitute x = y - b/3 for a cubic with no squared term: y^3 + p*y + q = 0.
+	p := c - b*b/3
+	q := 2*b*b*b/27 - b*c/3 + d
+	shift := -b / 3
+	if p == 0 {
+		return []float64{math.Cbrt(-q) + shift}
+	}
+	disc := q*q/4 + p*p*p/27
+	if disc > 0 {
+		s := math.Sqrt(disc)
+		return []float64{math.Cbrt(-q/2+s) + math.Cbrt(-q/2-s) + shift}
+	}
+	// three real roots.
+	r := 2 * math.Sqrt(-p/3)
+	phi := math.Acos(math.Max(-1, math.Min(1, 3*q/(2*p)*math.Sqrt(-3/p)))) / 3
+	return []float64{
+		r*math.Cos(phi) + shift,
+		r*math.Cos(phi-2*math.Pi/3) + shift,
+		r*math.Cos(phi-4*math.Pi/3) + shift,
+	}
+}
@@ -0,0 +1,166 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFlattenBezierPath(t *testing.T) {
+	path := []*Point{
+		NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10), NewPoint(10, 0),
+		NewPoint(10, -10), NewPoint(20, -10), NewPoint(20, 0),
+	}
+	points := FlattenBezierPath(path, 0.1)
+	if points[0] != path[0] || points[len(points)-1].Distance(path[6]) > 1e-9 {
+		t.Errorf("flattened path doesn't keep its end points")
+	}
+	for _, p := range points {
+		if p.Y > 7.5+1e-9 || p.Y < -7.5-1e-9 {
+			t.Errorf("point %v is outside the curves", p)
+		}
+	}
+}
+
+func TestBezierPath(t *testing.T) {
+	path := []*Point{
+		NewPoint(0, 0), NewPoint(10, 0), NewPoint(20, 0), NewPoint(30, 0),
+		NewPoint(30, 10), NewPoint(30, 20), NewPoint(30, 30),
+	}
+	if p := BezierPathPoint(path, 0.5); p.Distance(NewPoint(30, 0)) > 1e-9 {
+		t.Errorf("middle of path is %v, want 30, 0", p)
+	}
+	if p := BezierPathPoint(path, 0.75); p.Distance(NewPoint(30, 15)) > 1e-9 {
+		t.Errorf("point at 0.75 is %v, want 30, 15", p)
+	}
+	// each straight curve covers 30 in half of t.
+	if d := BezierPathTangent(path, 0.25); d.Distance(NewPoint(60, 0)) > 1e-9 {
+		t.Errorf("tangent at 0.25 is %v, want 60, 0", d)
+	}
+	if d := BezierPathTangent(path, 1); d.Distance(NewPoint(0, 60)) > 1e-9 {
+		t.Errorf("tangent at end is %v, want 0, 60", d)
+	}
+}
+
+// arch is a symmetric curve from 0, 0 to 30, 0 peaking at y = 15.
+func arch() []*Point {
+	return []*Point{NewPoint(0, 0), NewPoint(0, 20), NewPoint(30, 20), NewPoint(30, 0)}
+}
+
+func TestBezierLength(t *testing.T) {
+	line := []*Point{NewPoint(0, 0), NewPoint(10, 0), NewPoint(20, 0), NewPoint(30, 0)}
+	if l := BezierLength(line[0], line[1], line[2], line[3]); math.Abs(l-30) > 1e-9 {
+		t.Errorf("straight length %f, want 30", l)
+	}
+	a := arch()
+	flat := PathLength(FlattenBezier(a[0], a[1], a[2], a[3], 1e-4))
+	if l := BezierLength(a[0], a[1], a[2], a[3]); math.Abs(l-flat) > 1e-3 {
+		t.Errorf("arch length %f, want %f", l, flat)
+	}
+
+	// half the length is at the middle of a symmetric curve.
+	half := BezierLength(a[0], a[1], a[2], a[3]) / 2
+	if t0 := BezierParamAtLength(a[0], a[1], a[2], a[3], half); math.Abs(t0-0.5) > 1e-6 {
+		t.Errorf("t at half length %f, want 0.5", t0)
+	}
+	// uneven control points make t uneven, but not length.
+	uneven := []*Point{NewPoint(0, 0), NewPoint(1, 0), NewPoint(2, 0), NewPoint(30, 0)}
+	t0 := BezierParamAtLength(uneven[0], uneven[1], uneven[2], uneven[3], 15)
+	if p := BezierPoint(uneven[0], uneven[1], uneven[2], uneven[3], t0); math.Abs(p.X-15) > 1e-6 {
+		t.Errorf("point at length 15 is %v", p)
+	}
+}
+
+func TestSplitBezier(t *testing.T) {
+	a := arch()
+	left, right := SplitBezier(a[0], a[1], a[2], a[3], 0.3)
+	for i := 0; i <= 10; i++ {
+		u := float64(i) / 10
+		if p, q := BezierPoint(left[0], left[1], left[2], left[3], u), BezierPoint(a[0], a[1], a[2], a[3], u*0.3); p.Distance(q) > 1e-9 {
+			t.Errorf("left part at %f is %v, want %v", u, p, q)
+		}
+		if p, q := BezierPoint(right[0], right[1], right[2], right[3], u), BezierPoint(a[0], a[1], a[2], a[3], 0.3+u*0.7); p.Distance(q) > 1e-9 {
+			t.Errorf("right part at %f is %v, want %v", u, p, q)
+		}
+	}
+}
+
+func TestBezierBounds(t *testing.T) {
+	a := arch()
+	bounds := BezierBounds(a[0], a[1], a[2], a[3])
+	if math.Abs(bounds.X) > 1e-9 || math.Abs(bounds.Y) > 1e-9 || math.Abs(bounds.W-30) > 1e-9 || math.Abs(bounds.H-15) > 1e-9 {
+		t.Errorf("bounds %v, want 0, 0, 30, 15", bounds)
+	}
+}
+
+func TestBezierTangentNormal(t *testing.T) {
+	a := arch()
+	if d := BezierTangent(a[0], a[1], a[2], a[3], 0.5); d.Distance(NewPoint(1, 0)) > 1e-9 {
+		t.Errorf("tangent at top %v, want 1, 0", d)
+	}
+	if d := BezierTangent(a[0], a[1], a[2], a[3], 0); d.Distance(NewPoint(0, 1)) > 1e-9 {
+		t.Errorf("tangent at start %v, want 0, 1", d)
+	}
+	if n := BezierNormal(a[0], a[1], a[2], a[3], 0.5); n.Distance(NewPoint(0, -1)) > 1e-9 {
+		t.Errorf("normal at top %v, want 0, -1", n)
+	}
+}
+
+func TestNearestPointOnBezier(t *testing.T) {
+	a := arch()
+	p, t0 := NearestPointOnBezier(NewPoint(15, 30), a[0], a[1], a[2], a[3])
+	if p.Distance(NewPoint(15, 15)) > 1e-6 || math.Abs(t0-0.5) > 1e-6 {
+		t.Errorf("nearest point %v at %f, want 15, 15 at 0.5", p, t0)
+	}
+}
+
+func TestBezierIntersections(t *testing.T) {
+	a := arch()
+	points := BezierLineIntersections(a[0], a[1], a[2], a[3], NewPoint(-10, 10), NewPoint(40, 10))
+	if len(points) != 2 {
+		t.Fatalf("curve crosses line %d times, want 2", len(points))
+	}
+	for _, p := range points {
+		if math.Abs(p.Y-10) > 1e-9 {
+			t.Errorf("crossing %v is not on the line", p)
+		}
+	}
+	if points := BezierLineIntersections(a[0], a[1], a[2], a[3], NewPoint(-10, 10), NewPoint(5, 10)); len(points) != 1 {
+		t.Errorf("curve crosses short line %d times, want 1", len(points))
+	}
+
+	// the arch and its mirror image cross at both ends.
+	b := []*Point{NewPoint(0, 10), NewPoint(0, -10), NewPoint(30, -10), NewPoint(30, 10)}
+	points = BezierIntersections(a[0], a[1], a[2], a[3], b[0], b[1], b[2], b[3])
+	if len(points) != 2 {
+		t.Fatalf("curves cross %d times, want 2", len(points))
+	}
+	for _, p := range points {
+		if math.Abs(p.Y-5) > 1e-6 {
+			t.Errorf("crossing %v, want y of 5", p)
+		}
+	}
+}
+
+func TestBezierIntersectionsOverlap(t *testing.T) {
+	a := arch()
+	points := BezierIntersections(a[0], a[1], a[2], a[3], a[0], a[1], a[2], a[3])
+	if len(points) != 2 || points[0].Distance(a[0]) > 1e-6 || points[1].Distance(a[3]) > 1e-6 {
+		t.Errorf("identical curves gave %v, want their end points", points)
+	}
+
+	// two pieces of the arch sharing the stretch from t = 0.4 to t = 0.6.
+	first, _ := SplitBezier(a[0], a[1], a[2], a[3], 0.6)
+	_, second := SplitBezier(a[0], a[1], a[2], a[3], 0.4)
+	points = BezierIntersections(first[0], first[1], first[2], first[3], second[0], second[1], second[2], second[3])
+	want := []*Point{BezierPoint(a[0], a[1], a[2], a[3], 0.4), BezierPoint(a[0], a[1], a[2], a[3], 0.6)}
+	if len(points) != 2 || points[0].Distance(want[0]) > 1e-6 || points[1].Distance(want[1]) > 1e-6 {
+		t.Errorf("overlapping curves gave %v, want %v", points, want)
+	}
+
+	// pieces that meet end to end touch at one point.
+	_, rest := SplitBezier(a[0], a[1], a[2], a[3], 0.6)
+	points = BezierIntersections(first[0], first[1], first[2], first[3], rest[0], rest[1], rest[2], rest[3])
+	if len(points) != 1 || points[0].Distance(first[3]) > 1e-6 {
+		t.Errorf("curves meeting end to end gave %v, want %v", points, first[3])
+	}
+}
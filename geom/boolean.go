@@ -0,0 +1,438 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// PolygonUnion returns the area covered by either polygon.
+func PolygonUnion(a, b Polygon) Polygon {
+	return booleanOp(a, b, func(inA, inB bool) bool { return inA || inB })
+}
+
+// PolygonIntersection returns the area covered by both polygons.
+func PolygonIntersection(a, b Polygon) Polygon {
+	return booleanOp(a, b, func(inA, inB bool) bool { return inA && inB })
+}
+
+// PolygonDifference returns the area covered by polygon a but not polygon b.
+func PolygonDifference(a, b Polygon) Polygon {
+	return booleanOp(a, b, func(inA, inB bool) bool { return inA && !inB })
+}
+
+// PolygonXor returns the area covered by exactly one of the polygons.
+func PolygonXor(a, b Polygon) Polygon {
+	return booleanOp(a, b, func(inA, inB bool) bool { return inA != inB })
+}
+
+func booleanOp(a, b Polygon, op func(inA, inB bool) bool) Polygon {
+	return resolve([]Polygon{a, b}, func(p *Point) bool {
+		return op(PointInPolygon(p, a), PointInPolygon(p, b))
+	})
+}
+
+// unionAll returns the area covered by any of a number of polygons.
+func unionAll(polygons []Polygon) Polygon {
+	index := newPolygonIndex(polygons)
+	return resolve(polygons, index.inAny)
+}
+
+// resolve splits the edges of the polygons wherever they cross, then keeps each piece
+// that separates an area inside the result from one outside of it.
+// Kept edges are directed with the inside on their left and joined into rings.
+// As every ring winds the same way around its area, results fill correctly
+// with either the even-odd or non-zero rule.
+func resolve(polygons []Polygon, inside func(p *Point) bool) Polygon {
+	var edges []*splitEdge
+	for _, polygon := range polygons {
+		edges = append(edges, polygonEdges(polygon)...)
+	}
+	if len(edges) == 0 {
+		return nil
+	}
+	bounds := edgeBounds(edges)
+	eps := math.Max(bounds.W, bounds.H) * 1e-9
+	if eps == 0 {
+		return nil
+	}
+	splitEdges(edges, bounds)
+
+	vertices := newVertexIndex(eps)
+	type directed struct{ from, to int }
+	seen := map[directed]bool{}
+	var kept []directed
+	offset := eps * 100
+	for _, e := range edges {
+		points := e.points()
+		for i := 1; i < len(points); i++ {
+			p, q := points[i-1], points[i]
+			length := p.Distance(q)
+			if length <= eps {
+				continue
+			}
+			// sample just either side of the midpoint.
+			nx, ny := -(q.Y-p.Y)/length*offset, (q.X-p.X)/length*offset
+			mx, my := (p.X+q.X)/2, (p.Y+q.Y)/2
+			left := inside(NewPoint(mx+nx, my+ny))
+			right := inside(NewPoint(mx-nx, my-ny))
+			if left == right {
+				continue
+			}
+			d := directed{vertices.index(p), vertices.index(q)}
+			if right {
+				d.from, d.to = d.to, d.from
+			}
+			if d.from != d.to && !seen[d] {
+				seen[d] = true
+				kept = append(kept, d)
+			}
+		}
+	}
+
+	// join edges into rings.
+	outgoing := map[int][]int{}
+	for i, d := range kept {
+		outgoing[d.from] = append(outgoing[d.from], i)
+	}
+	used := make([]bool, len(kept))
+	// where rings touch at a vertex, continue along the edge that keeps the inside on the left,
+	// the first one clockwise from the edge just walked, so touching rings stay separate.
+	nextEdge := func(e int, candidates []int) int {
+		from, to := vertices.points[kept[e].from], vertices.points[kept[e].to]
+		back := math.Atan2(from.Y-to.Y, from.X-to.X)
+		next, best := -1, math.MaxFloat64
+		for _, c := range candidates {
+			if used[c] {
+				continue
+			}
+			p := vertices.points[kept[c].to]
+			angle := back - math.Atan2(p.Y-to.Y, p.X-to.X)
+			for angle <= 0 {
+				angle += 2 * math.Pi
+			}
+			if angle < best {
+				next, best = c, angle
+			}
+		}
+		return next
+	}
+	var result Polygon
+	for i := range kept {
+		if used[i] {
+			continue
+		}
+		var ring []*Point
+		for e := i; e >= 0 && !used[e]; e = nextEdge(e, outgoing[kept[e].to]) {
+			used[e] = true
+			ring = append(ring, vertices.points[kept[e].from])
+		}
+		ring = removeCollinear(ring, eps)
+		if len(ring) >= 3 {
+			result = append(result, ring)
+		}
+	}
+	return result
+}
+
+// splitEdge is a polygon edge, with any points where other edges cross it.
+type splitEdge struct {
+	p0, p1 *Point
+	splits []split
+}
+
+type split struct {
+	t float64
+	p *Point
+}
+
+func polygonEdges(polygon Polygon) []*splitEdge {
+	var edges []*splitEdge
+	for _, ring := range polygon {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			p0, p1 := ring[i], ring[(i+1)%n]
+			if p0.X != p1.X || p0.Y != p1.Y {
+				edges = append(edges, &splitEdge{p0: p0, p1: p1})
+			}
+		}
+	}
+	return edges
+}
+
+func edgeBounds(edges []*splitEdge) *Rectangle {
+	points := make([]*Point, 0, len(edges)*2)
+	for _, e := range edges {
+		points = append(points, e.p0, e.p1)
+	}
+	return BoundingBox(points)
+}
+
+// points returns the edge's points, ordered along it, including the end points.
+func (e *splitEdge) points() []*Point {
+	sort.Slice(e.splits, func(i, j int) bool {
+		return e.splits[i].t < e.splits[j].t
+	})
+	points := []*Point{e.p0}
+	for _, s := range e.splits {
+		points = append(points, s.p)
+	}
+	return append(points, e.p1)
+}
+
+// param returns how far along the edge a point is, from 0.0 to 1.0.
+func (e *splitEdge) param(p *Point) float64 {
+	dx, dy := e.p1.X-e.p0.X, e.p1.Y-e.p0.Y
+	return ((p.X-e.p0.X)*dx + (p.Y-e.p0.Y)*dy) / (dx*dx + dy*dy)
+}
+
+func (e *splitEdge) box() box {
+	return newBox(e.p0, e.p1)
+}
+
+// splitEdges finds every crossing between edges and records it on both edges.
+// Edges of the same polygon are included, as rings may cross each other.
+func splitEdges(edges []*splitEdge, bounds *Rectangle) {
+	g := newGrid(bounds, len(edges))
+	boxes := make([]box, len(edges))
+	for i, e := range edges {
+		boxes[i] = e.box()
+		g.insert(i, boxes[i])
+	}
+	// tested holds the last edge each edge was tested against, so pairs sharing many cells are tested once.
+	tested := make([]int, len(edges))
+	for i := range tested {
+		tested[i] = -1
+	}
+	for i, a := range edges {
+		g.each(boxes[i], func(j int) {
+			if j <= i || tested[j] == i || !boxes[i].overlaps(boxes[j]) {
+				return
+			}
+			tested[j] = i
+			splitPair(a, edges[j])
+		})
+	}
+}
+
+// splitPair records the crossing of two edges, if any, on both of them.
+func splitPair(a, b *splitEdge) {
+	d1x, d1y := a.p1.X-a.p0.X, a.p1.Y-a.p0.Y
+	d2x, d2y := b.p1.X-b.p0.X, b.p1.Y-b.p0.Y
+	denom := d1x*d2y - d1y*d2x
+	ex, ey := b.p0.X-a.p0.X, b.p0.Y-a.p0.Y
+	if math.Abs(denom) <= 1e-12*math.Hypot(d1x, d1y)*math.Hypot(d2x, d2y) {
+		// parallel. if collinear, each edge is split by the other's end points.
+		if math.Abs(ex*d1y-ey*d1x) > 1e-9*math.Hypot(d1x, d1y)*math.Hypot(ex, ey) {
+			return
+		}
+		for _, p := range []*Point{b.p0, b.p1} {
+			if t := a.param(p); t > 0 && t < 1 {
+				a.splits = append(a.splits, split{t, p})
+			}
+		}
+		for _, p := range []*Point{a.p0, a.p1} {
+			if t := b.param(p); t > 0 && t < 1 {
+				b.splits = append(b.splits, split{t, p})
+			}
+		}
+		return
+	}
+	t := (ex*d2y - ey*d2x) / denom
+	u := (ex*d1y - ey*d1x) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return
+	}
+	p := NewPoint(a.p0.X+d1x*t, a.p0.Y+d1y*t)
+	if t > 0 && t < 1 {
+		a.splits = append(a.splits, split{t, p})
+	}
+	if u > 0 && u < 1 {
+		b.splits = append(b.splits, split{u, p})
+	}
+}
+
+// vertexIndex merges points closer than eps into a single indexed vertex.
+type vertexIndex struct {
+	eps    float64
+	cells  map[[2]int64][]int
+	points []*Point
+}
+
+func newVertexIndex(eps float64) *vertexIndex {
+	return &vertexIndex{
+		eps:   eps,
+		cells: map[[2]int64][]int{},
+	}
+}
+
+func (v *vertexIndex) index(p *Point) int {
+	cx, cy := int64(math.Floor(p.X/v.eps)), int64(math.Floor(p.Y/v.eps))
+	for x := cx - 1; x <= cx+1; x++ {
+		for y := cy - 1; y <= cy+1; y++ {
+			for _, i := range v.cells[[2]int64{x, y}] {
+				if v.points[i].Distance(p) <= v.eps {
+					return i
+				}
+			}
+		}
+	}
+	i := len(v.points)
+	v.points = append(v.points, p)
+	v.cells[[2]int64{cx, cy}] = append(v.cells[[2]int64{cx, cy}], i)
+	return i
+}
+
+// removeCollinear removes points from a closed ring that lie on a straight line between their neighbors.
+func removeCollinear(ring []*Point, eps float64) []*Point {
+	for len(ring) >= 3 {
+		n := len(ring)
+		var result []*Point
+		for i, p := range ring {
+			prev := ring[(i-1+n)%n]
+			if len(result) > 0 {
+				prev = result[len(result)-1]
+			}
+			if DistanceToSegment(p, prev, ring[(i+1)%n]) > eps {
+				result = append(result, p)
+			}
+		}
+		if len(result) == n {
+			break
+		}
+		ring = result
+	}
+	return ring
+}
+
+////////////////////////////////////////
+// Spatial indexing
+////////////////////////////////////////
+
+// box is an axis aligned bounding box.
+type box struct {
+	minX, minY, maxX, maxY float64
+}
+
+func newBox(points ...*Point) box {
+	b := box{math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64}
+	for _, p := range points {
+		b.minX = math.Min(b.minX, p.X)
+		b.minY = math.Min(b.minY, p.Y)
+		b.maxX = math.Max(b.maxX, p.X)
+		b.maxY = math.Max(b.maxY, p.Y)
+	}
+	return b
+}
+
+func (b box) overlaps(o box) bool {
+	return b.minX <= o.maxX && o.minX <= b.maxX && b.minY <= o.maxY && o.minY <= b.maxY
+}
+
+func (b box) contains(p *Point) bool {
+	return p.X >= b.minX && p.X <= b.maxX && p.Y >= b.minY && p.Y <= b.maxY
+}
+
+// grid is a uniform grid of cells, each holding the ids of the boxes that overlap it.
+type grid struct {
+	x, y, size float64
+	cols, rows int
+	cells      [][]int
+}
+
+// newGrid creates a grid over the bounds, sized for roughly count items.
+func newGrid(bounds *Rectangle, count int) *grid {
+	cells := int(math.Max(1, math.Sqrt(float64(count))))
+	size := math.Max(bounds.W, bounds.H) / float64(cells)
+	if size <= 0 {
+		size = 1
+	}
+	g := &grid{
+		x:    bounds.X,
+		y:    bounds.Y,
+		size: size,
+		cols: int(bounds.W/size) + 1,
+		rows: int(bounds.H/size) + 1,
+	}
+	g.cells = make([][]int, g.cols*g.rows)
+	return g
+}
+
+func (g *grid) cell(x, y float64) (int, int) {
+	col := int(math.Floor((x - g.x) / g.size))
+	row := int(math.Floor((y - g.y) / g.size))
+	if col < 0 {
+		col = 0
+	}
+	if col >= g.cols {
+		col = g.cols - 1
+	}
+	if row < 0 {
+		row = 0
+	}
+	if row >= g.rows {
+		row = g.rows - 1
+	}
+	return col, row
+}
+
+func (g *grid) insert(id int, b box) {
+	c0, r0 := g.cell(b.minX, b.minY)
+	c1, r1 := g.cell(b.maxX, b.maxY)
+	for r := r0; r <= r1; r++ {
+		for c := c0; c <= c1; c++ {
+			g.cells[r*g.cols+c] = append(g.cells[r*g.cols+c], id)
+		}
+	}
+}
+
+// each calls a function for every id in the cells a box overlaps. Ids may repeat.
+func (g *grid) each(b box, f func(id int)) {
+	c0, r0 := g.cell(b.minX, b.minY)
+	c1, r1 := g.cell(b.maxX, b.maxY)
+	for r := r0; r <= r1; r++ {
+		for c := c0; c <= c1; c++ {
+			for _, id := range g.cells[r*g.cols+c] {
+				f(id)
+			}
+		}
+	}
+}
+
+// polygonIndex finds which of a number of polygons contain a point.
+type polygonIndex struct {
+	polygons []Polygon
+	boxes    []box
+	grid     *grid
+}
+
+func newPolygonIndex(polygons []Polygon) *polygonIndex {
+	index := &polygonIndex{
+		polygons: polygons,
+		boxes:    make([]box, len(polygons)),
+	}
+	var all []*Point
+	for i, polygon := range polygons {
+		var points []*Point
+		for _, ring := range polygon {
+			points = append(points, ring...)
+		}
+		index.boxes[i] = newBox(points...)
+		all = append(all, points...)
+	}
+	index.grid = newGrid(BoundingBox(all), len(polygons))
+	for i, b := range index.boxes {
+		index.grid.insert(i, b)
+	}
+	return index
+}
+
+// inAny returns whether or not a point is inside any of the polygons.
+func (p *polygonIndex) inAny(point *Point) bool {
+	c, r := p.grid.cell(point.X, point.Y)
+	for _, i := range p.grid.cells[r*p.grid.cols+c] {
+		if p.boxes[i].contains(point) && PointInPolygon(point, p.polygons[i]) {
+			return true
+		}
+	}
+	return false
+}
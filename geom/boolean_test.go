@@ -0,0 +1,86 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func square(x, y, size float64) Polygon {
+	return Polygon{{
+		NewPoint(x, y),
+		NewPoint(x+size, y),
+		NewPoint(x+size, y+size),
+		NewPoint(x, y+size),
+	}}
+}
+
+func TestPolygonBooleans(t *testing.T) {
+	a := square(0, 0, 2)
+	b := square(1, 1, 2)
+	var tests = []struct {
+		name  string
+		op    func(a, b Polygon) Polygon
+		rings int
+		area  float64
+	}{
+		{"union", PolygonUnion, 1, 7},
+		{"intersection", PolygonIntersection, 1, 1},
+		{"difference", PolygonDifference, 1, 3},
+		{"xor", PolygonXor, 2, 6},
+	}
+	for _, test := range tests {
+		result := test.op(a, b)
+		if len(result) != test.rings {
+			t.Errorf("%s has %d rings, want %d", test.name, len(result), test.rings)
+		}
+		if math.Abs(result.Area()-test.area) > 1e-9 {
+			t.Errorf("%s area %f, want %f", test.name, result.Area(), test.area)
+		}
+	}
+}
+
+func TestPolygonSharedEdge(t *testing.T) {
+	result := PolygonUnion(square(0, 0, 1), square(1, 0, 1))
+	if len(result) != 1 || len(result[0]) != 4 {
+		t.Fatalf("union of adjacent squares %v, want one 4 point ring", result)
+	}
+	if math.Abs(result.Area()-2) > 1e-9 {
+		t.Errorf("area %f, want 2", result.Area())
+	}
+	if len(PolygonIntersection(square(0, 0, 1), square(1, 0, 1))) != 0 {
+		t.Errorf("intersection of adjacent squares is not empty")
+	}
+}
+
+func TestPolygonHoles(t *testing.T) {
+	result := PolygonDifference(square(0, 0, 10), square(4, 4, 2))
+	if len(result) != 2 {
+		t.Fatalf("difference has %d rings, want 2", len(result))
+	}
+	if math.Abs(result.Area()-96) > 1e-9 {
+		t.Errorf("area %f, want 96", result.Area())
+	}
+	if PointInPolygon(NewPoint(5, 5), result) {
+		t.Errorf("point in hole is inside")
+	}
+	if !PointInPolygon(NewPoint(1, 1), result) {
+		t.Errorf("point outside hole is not inside")
+	}
+
+	// cutting a shape across the hole.
+	cut := PolygonIntersection(result, square(5, -1, 11))
+	if math.Abs(cut.Area()-48) > 1e-9 {
+		t.Errorf("cut area %f, want 48", cut.Area())
+	}
+}
+
+func TestPolygonDisjoint(t *testing.T) {
+	a := square(0, 0, 1)
+	b := square(5, 5, 1)
+	if len(PolygonUnion(a, b)) != 2 {
+		t.Errorf("union of disjoint squares should have 2 rings")
+	}
+	if len(PolygonIntersection(a, b)) != 0 {
+		t.Errorf("intersection of disjoint squares should be empty")
+	}
+}
@@ -0,0 +1,121 @@
+package geom
+
+import "math"
+
+// PathParams returns how far along a path each of its points is, by length, from 0.0 at the start to 1.0 at the end.
+func PathParams(points []*Point) []float64 {
+	params := make([]float64, len(points))
+	if len(points) < 2 {
+		return params
+	}
+	for i := 1; i < len(points); i++ {
+		params[i] = params[i-1] + points[i-1].Distance(points[i])
+	}
+	if total := params[len(params)-1]; total > 0 {
+		for i := range params {
+			params[i] /= total
+		}
+	}
+	return params
+}
+
+// BrushOutline returns the closed outline of a stroke along a path, with a width for each point
+// and round caps at each end. Fill it with the non-zero rule, as tight bends may overlap.
+func BrushOutline(points []*Point, widths []float64) []*Point {
+	points, widths = removeDuplicateWidths(points, widths)
+	n := len(points)
+	if n == 0 {
+		return nil
+	}
+	if n == 1 {
+		return circleRing(points[0], widths[0]/2)
+	}
+	left := make([]*Point, n)
+	right := make([]*Point, n)
+	for i, p := range points {
+		nx, ny := pointNormal(points, i)
+		half := widths[i] / 2
+		left[i] = NewPoint(p.X+nx*half, p.Y+ny*half)
+		right[i] = NewPoint(p.X-nx*half, p.Y-ny*half)
+	}
+	outline := append([]*Point{}, left...)
+	outline = append(outline, capPoints(points[n-1], left[n-1], widths[n-1]/2)...)
+	for i := n - 1; i >= 0; i-- {
+		outline = append(outline, right[i])
+	}
+	return append(outline, capPoints(points[0], right[0], widths[0]/2)...)
+}
+
+// NibOutline returns the closed outline of a stroke along a path made by a flat calligraphic nib held at an angle.
+// Lines across the nib are width wide, and lines along it are thin.
+// Fill it with the non-zero rule, as the outline twists where the path turns along the nib.
+func NibOutline(points []*Point, width, thin, angle float64) []*Point {
+	points = removeDuplicates(points, false)
+	n := len(points)
+	if n < 2 {
+		return nil
+	}
+	dx, dy := math.Cos(angle)*width/2, math.Sin(angle)*width/2
+	outline := make([]*Point, 0, n*2)
+	var back []*Point
+	for i, p := range points {
+		// a thin pen of its own, at right angles to the path, keeps lines along the nib from vanishing.
+		nx, ny := pointNormal(points, i)
+		nx, ny = nx*thin/2, ny*thin/2
+		if nx*dx+ny*dy < 0 {
+			nx, ny = -nx, -ny
+		}
+		outline = append(outline, NewPoint(p.X+dx+nx, p.Y+dy+ny))
+		back = append(back, NewPoint(p.X-dx-nx, p.Y-dy-ny))
+	}
+	for i := n - 1; i >= 0; i-- {
+		outline = append(outline, back[i])
+	}
+	return outline
+}
+
+// pointNormal returns the unit normal to a path at one of its points, between the normals of the segments on either side.
+// At corners it is lengthened, so offsets keep their distance from both segments.
+func pointNormal(points []*Point, i int) (float64, float64) {
+	n := len(points)
+	if i == 0 {
+		return segmentNormal(points[0], points[1])
+	}
+	if i == n-1 {
+		return segmentNormal(points[n-2], points[n-1])
+	}
+	n0x, n0y := segmentNormal(points[i-1], points[i])
+	n1x, n1y := segmentNormal(points[i], points[i+1])
+	nx, ny := n0x+n1x, n0y+n1y
+	length := math.Hypot(nx, ny)
+	if length < 1e-9 {
+		return n0x, n0y
+	}
+	nx, ny = nx/length, ny/length
+	// limit the lengthening on sharp corners.
+	scale := 1 / math.Max(nx*n0x+ny*n0y, 0.5)
+	return nx * scale, ny * scale
+}
+
+// capPoints returns points around a round cap at the end of a path, from the side point around to the opposite side.
+func capPoints(end, side *Point, radius float64) []*Point {
+	if radius <= 0 {
+		return nil
+	}
+	start := math.Atan2(side.Y-end.Y, side.X-end.X)
+	points := arcPoints(end, radius, start, math.Pi)
+	return points[1 : len(points)-1]
+}
+
+// removeDuplicateWidths removes consecutive repeated points from a path, along with their widths.
+func removeDuplicateWidths(points []*Point, widths []float64) ([]*Point, []float64) {
+	var p []*Point
+	var w []float64
+	for i, point := range points {
+		if i < len(widths) && (len(p) == 0 || p[len(p)-1].Distance(point) > 1e-12) {
+			p = append(p, point)
+			w = append(w, widths[i])
+		}
+	}
+	return p, w
+}
@@ -0,0 +1,43 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathParams(t *testing.T) {
+	params := PathParams([]*Point{NewPoint(0, 0), NewPoint(1, 0), NewPoint(1, 3)})
+	want := []float64{0, 0.25, 1}
+	for i, p := range want {
+		if math.Abs(params[i]-p) > 1e-9 {
+			t.Errorf("param %d is %f, want %f", i, params[i], p)
+		}
+	}
+}
+
+func TestBrushOutline(t *testing.T) {
+	line := []*Point{NewPoint(0, 0), NewPoint(50, 0), NewPoint(100, 0)}
+	outline := BrushOutline(line, []float64{20, 20, 20})
+	area := math.Abs(RingArea(outline))
+	if want := 2000 + math.Pi*100; math.Abs(area-want) > 10 {
+		t.Errorf("outline area %f, want about %f", area, want)
+	}
+
+	// tapering to nothing leaves the end points on the path.
+	outline = BrushOutline(line, []float64{0, 20, 0})
+	if math.Abs(RingArea(outline)-1000) > 1e-9 {
+		t.Errorf("tapered outline area %f, want 1000", math.Abs(RingArea(outline)))
+	}
+}
+
+func TestNibOutline(t *testing.T) {
+	line := []*Point{NewPoint(0, 0), NewPoint(100, 0)}
+	across := math.Abs(RingArea(NibOutline(line, 10, 0, math.Pi/2)))
+	if math.Abs(across-1000) > 1e-9 {
+		t.Errorf("line across nib has area %f, want 1000", across)
+	}
+	along := math.Abs(RingArea(NibOutline(line, 10, 2, 0)))
+	if math.Abs(along-200) > 1e-9 {
+		t.Errorf("line along nib has area %f, want 200", along)
+	}
+}
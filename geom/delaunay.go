@@ -0,0 +1,254 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// Triangulation is a Delaunay triangulation of a set of points, where no point lies inside the
+// circumcircle of any triangle. Triangles hold the indices of their three corners in Points.
+type Triangulation struct {
+	Points    []*Point
+	Triangles [][3]int
+	neighbors [][]int
+	inserted  []bool
+}
+
+// delaunayTriangle is a triangle being built, with its corners in anticlockwise order (with y up)
+// and the index of the triangle across each edge from corner i to corner i+1, or -1 if there is none.
+type delaunayTriangle struct {
+	v    [3]int
+	n    [3]int
+	dead bool
+}
+
+// Delaunay returns the Delaunay triangulation of a set of points, using the Bowyer-Watson algorithm.
+// Repeated points are left out of the triangulation.
+func Delaunay(points []*Point) *Triangulation {
+	return delaunay(points, BoundingBox(points))
+}
+
+// delaunay triangulates points, starting from a super triangle far enough outside the extent
+// that the triangulation is correct throughout it.
+func delaunay(points []*Point, extent *Rectangle) *Triangulation {
+	n := len(points)
+	t := &Triangulation{
+		Points:    points,
+		neighbors: make([][]int, n),
+		inserted:  make([]bool, n),
+	}
+	if n == 0 {
+		return t
+	}
+	size := math.Max(math.Max(extent.W, extent.H), 1) * 100
+	cx, cy := extent.X+extent.W/2, extent.Y+extent.H/2
+	all := append(points[:n:n],
+		NewPoint(cx, cy-2*size),
+		NewPoint(cx+math.Sqrt(3)*size, cy+size),
+		NewPoint(cx-math.Sqrt(3)*size, cy+size),
+	)
+	tris := []delaunayTriangle{{v: [3]int{n, n + 1, n + 2}, n: [3]int{-1, -1, -1}}}
+
+	// locate walks from triangle i towards p, returning the triangle containing it, or -1.
+	locate := func(i int, p *Point) int {
+		for steps := 0; steps < len(tris); steps++ {
+			moved := false
+			for e := 0; e < 3; e++ {
+				tri := tris[i]
+				if orient(all[tri.v[e]], all[tri.v[(e+1)%3]], p) < 0 {
+					i = tri.n[e]
+					moved = true
+					break
+				}
+			}
+			if i < 0 || !moved {
+				return i
+			}
+		}
+		// the walk can cycle through nearly flat triangles, so fall back to searching them all.
+		for i, tri := range tris {
+			if !tri.dead && orient(all[tri.v[0]], all[tri.v[1]], p) >= 0 &&
+				orient(all[tri.v[1]], all[tri.v[2]], p) >= 0 && orient(all[tri.v[2]], all[tri.v[0]], p) >= 0 {
+				return i
+			}
+		}
+		return -1
+	}
+
+	last := 0
+	for _, i := range localOrder(points, extent) {
+		p := points[i]
+		start := locate(last, p)
+		if start < 0 {
+			continue
+		}
+		duplicate := false
+		for _, v := range tris[start].v {
+			duplicate = duplicate || all[v].Distance(p) < 1e-12
+		}
+		if duplicate {
+			continue
+		}
+		t.inserted[i] = true
+
+		// remove every triangle whose circumcircle contains p, leaving a cavity around it.
+		bad := []int{start}
+		tris[start].dead = true
+		for k := 0; k < len(bad); k++ {
+			for _, nb := range tris[bad[k]].n {
+				if nb >= 0 && !tris[nb].dead && inCircumcircle(all, tris[nb].v, p) {
+					tris[nb].dead = true
+					bad = append(bad, nb)
+				}
+			}
+		}
+
+		// fill the cavity with triangles joining p to each edge around it.
+		startAt := map[int]int{}
+		endAt := map[int]int{}
+		var added []int
+		for _, b := range bad {
+			for e := 0; e < 3; e++ {
+				nb := tris[b].n[e]
+				if nb >= 0 && tris[nb].dead {
+					continue
+				}
+				v0, v1 := tris[b].v[e], tris[b].v[(e+1)%3]
+				index := len(tris)
+				tris = append(tris, delaunayTriangle{v: [3]int{v0, v1, i}, n: [3]int{nb, -1, -1}})
+				if nb >= 0 {
+					for k := range tris[nb].n {
+						if tris[nb].n[k] == b {
+							tris[nb].n[k] = index
+						}
+					}
+				}
+				startAt[v0] = index
+				endAt[v1] = index
+				added = append(added, index)
+			}
+		}
+		for _, index := range added {
+			tris[index].n[1] = startAt[tris[index].v[1]]
+			tris[index].n[2] = endAt[tris[index].v[0]]
+		}
+		last = added[0]
+	}
+
+	// with the super triangle around them, every edge between real points is shared by two triangles.
+	for _, tri := range tris {
+		if tri.dead {
+			continue
+		}
+		for e := 0; e < 3; e++ {
+			a, b := tri.v[e], tri.v[(e+1)%3]
+			if a < b && b < n {
+				t.neighbors[a] = append(t.neighbors[a], b)
+				t.neighbors[b] = append(t.neighbors[b], a)
+			}
+		}
+		if tri.v[0] < n && tri.v[1] < n && tri.v[2] < n {
+			t.Triangles = append(t.Triangles, tri.v)
+		}
+	}
+	return t
+}
+
+// localOrder returns the indices of points sorted in a zigzag through rows of a grid,
+// so each point is inserted near the last one.
+func localOrder(points []*Point, extent *Rectangle) []int {
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	rows := math.Max(math.Floor(math.Sqrt(float64(len(points))/2)), 1)
+	cell := math.Max(extent.H/rows, 1e-12)
+	row := func(p *Point) int {
+		return int((p.Y - extent.Y) / cell)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := points[order[i]], points[order[j]]
+		ra, rb := row(a), row(b)
+		if ra != rb {
+			return ra < rb
+		}
+		if ra%2 == 0 {
+			return a.X < b.X
+		}
+		return a.X > b.X
+	})
+	return order
+}
+
+// orient returns a positive number if a, b, c turn anticlockwise with y up, negative if clockwise, and 0 if in line.
+func orient(a, b, c *Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// inCircumcircle returns whether p is inside the circumcircle of an anticlockwise triangle.
+func inCircumcircle(points []*Point, v [3]int, p *Point) bool {
+	a, b, c := points[v[0]], points[v[1]], points[v[2]]
+	adx, ady := a.X-p.X, a.Y-p.Y
+	bdx, bdy := b.X-p.X, b.Y-p.Y
+	cdx, cdy := c.X-p.X, c.Y-p.Y
+	det := (adx*adx+ady*ady)*(bdx*cdy-cdx*bdy) +
+		(bdx*bdx+bdy*bdy)*(cdx*ady-adx*cdy) +
+		(cdx*cdx+cdy*cdy)*(adx*bdy-bdx*ady)
+	return det > 0
+}
+
+// Neighbors returns the indices of the points joined to point i by an edge of the triangulation.
+// These are also the points whose Voronoi cells border its own.
+func (t *Triangulation) Neighbors(i int) []int {
+	return t.neighbors[i]
+}
+
+// Edges returns every edge of the triangulation once, as the indices of the points at either end.
+func (t *Triangulation) Edges() [][2]int {
+	var edges [][2]int
+	for i, neighbors := range t.neighbors {
+		for _, j := range neighbors {
+			if i < j {
+				edges = append(edges, [2]int{i, j})
+			}
+		}
+	}
+	return edges
+}
+
+// TriangleRings returns each triangle as a ring of three points.
+func (t *Triangulation) TriangleRings() [][]*Point {
+	rings := make([][]*Point, len(t.Triangles))
+	for i, tri := range t.Triangles {
+		rings[i] = []*Point{t.Points[tri[0]], t.Points[tri[1]], t.Points[tri[2]]}
+	}
+	return rings
+}
+
+// Nearest returns the index of the point closest to p, or -1 if there are no points.
+// It walks the triangulation from neighbor to closer neighbor, which is quicker than checking every point.
+func (t *Triangulation) Nearest(p *Point) int {
+	current := -1
+	for i, inserted := range t.inserted {
+		if inserted {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		return -1
+	}
+	dist := t.Points[current].Distance(p)
+	for {
+		next := current
+		for _, j := range t.neighbors[current] {
+			if d := t.Points[j].Distance(p); d < dist {
+				next, dist = j, d
+			}
+		}
+		if next == current {
+			return current
+		}
+		current = next
+	}
+}
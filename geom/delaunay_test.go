@@ -0,0 +1,87 @@
+package geom
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func scattered(n int) []*Point {
+	r := rand.New(rand.NewSource(1))
+	points := make([]*Point, n)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*100, r.Float64()*100)
+	}
+	return points
+}
+
+func TestDelaunaySquare(t *testing.T) {
+	tri := Delaunay(square(0, 0, 10)[0])
+	if len(tri.Triangles) != 2 {
+		t.Errorf("square has %d triangles, want 2", len(tri.Triangles))
+	}
+	if len(tri.Edges()) != 5 {
+		t.Errorf("square has %d edges, want 5", len(tri.Edges()))
+	}
+}
+
+func TestDelaunayEmptyCircles(t *testing.T) {
+	points := scattered(200)
+	tri := Delaunay(points)
+	// a triangulation of points in general position has 2n - 2 - h triangles, for h points on the hull.
+	if len(tri.Triangles) < 2*len(points)-2-30 || len(tri.Triangles) > 2*len(points)-5 {
+		t.Errorf("got %d triangles for %d points", len(tri.Triangles), len(points))
+	}
+	area := 0.0
+	for _, v := range tri.Triangles {
+		area += math.Abs(RingArea([]*Point{points[v[0]], points[v[1]], points[v[2]]}))
+		for i, p := range points {
+			if i != v[0] && i != v[1] && i != v[2] && inCircumcircle(points, v, p) {
+				t.Fatalf("point %d is inside the circumcircle of %v", i, v)
+			}
+		}
+	}
+	if area > 100*100 || area < 80*80 {
+		t.Errorf("triangles cover %f, want about the hull of the points", area)
+	}
+}
+
+func TestDelaunayNeighbors(t *testing.T) {
+	points := scattered(100)
+	points = append(points, NewPoint(points[0].X, points[0].Y))
+	tri := Delaunay(points)
+	if len(tri.Neighbors(100)) != 0 {
+		t.Errorf("repeated point has neighbors %v", tri.Neighbors(100))
+	}
+	for i := range points {
+		for _, j := range tri.Neighbors(i) {
+			found := false
+			for _, k := range tri.Neighbors(j) {
+				found = found || k == i
+			}
+			if !found {
+				t.Errorf("%d neighbors %d, but not the other way around", i, j)
+			}
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	points := scattered(100)
+	tri := Delaunay(points)
+	for _, p := range scattered(20) {
+		p.Translate(3, -2)
+		want := 0
+		for i, q := range points {
+			if q.Distance(p) < points[want].Distance(p) {
+				want = i
+			}
+		}
+		if got := tri.Nearest(p); got != want {
+			t.Errorf("nearest to %v is %d, want %d", p, got, want)
+		}
+	}
+	if Delaunay(nil).Nearest(NewPoint(0, 0)) != -1 {
+		t.Errorf("nearest with no points should be -1")
+	}
+}
@@ -0,0 +1,243 @@
+package geom
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// ToneFunc returns how dark a fill should be at a point, from 0.0 for white to 1.0 for black.
+type ToneFunc func(x, y float64) float64
+
+// Hatch fills a polygon with parallel lines at an angle, spacing apart.
+// Lines fall on a grid shared by every shape, so neighboring shapes hatched the same way line up.
+// If tone is not nil, lines are left out where it is light:
+// they are spacing apart where the tone is 1.0, twice that where it is 0.5, and so on.
+func Hatch(polygon Polygon, angle, spacing float64, tone ToneFunc) [][]*Point {
+	if spacing <= 0 {
+		return nil
+	}
+	// hatch horizontally across a rotated copy of the polygon, then rotate the lines back.
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	rotated := make(Polygon, len(polygon))
+	for i, ring := range polygon {
+		rotated[i] = make([]*Point, len(ring))
+		for j, p := range ring {
+			rotated[i][j] = NewPoint(p.X*cos+p.Y*sin, p.Y*cos-p.X*sin)
+		}
+	}
+	bounds := rotated.Bounds()
+	var lines [][]*Point
+	for i := int(math.Ceil(bounds.Y / spacing)); float64(i)*spacing <= bounds.Y+bounds.H; i++ {
+		y := float64(i) * spacing
+		xs := scanline(rotated, y)
+		var row [][]*Point
+		for j := 0; j+1 < len(xs); j += 2 {
+			row = append(row, []*Point{
+				NewPoint(xs[j]*cos-y*sin, xs[j]*sin+y*cos),
+				NewPoint(xs[j+1]*cos-y*sin, xs[j+1]*sin+y*cos),
+			})
+		}
+		lines = append(lines, toned(row, i, spacing, tone)...)
+	}
+	return lines
+}
+
+// CrossHatch fills a polygon with two sets of parallel lines, at an angle and at right angles to it.
+// If tone is not nil, lines are left out where it is light, with only the first set drawn
+// where the tone is less than 0.5.
+func CrossHatch(polygon Polygon, angle, spacing float64, tone ToneFunc) [][]*Point {
+	var first, second ToneFunc
+	if tone != nil {
+		first = func(x, y float64) float64 { return tone(x, y) * 2 }
+		second = func(x, y float64) float64 { return tone(x, y)*2 - 1 }
+	}
+	return append(Hatch(polygon, angle, spacing, first), Hatch(polygon, angle+math.Pi/2, spacing, second)...)
+}
+
+// ContourFill fills a polygon with copies of its outline, inset by spacing each time.
+// Each contour is a closed path, ending on its first point.
+// If tone is not nil, contours are left out where it is light, as with Hatch.
+func ContourFill(polygon Polygon, spacing float64, tone ToneFunc) [][]*Point {
+	if spacing <= 0 {
+		return nil
+	}
+	var contours [][]*Point
+	for i := 0; ; i++ {
+		inset := OffsetPolygon(polygon, -(float64(i)+0.5)*spacing, JoinRound)
+		if len(inset) == 0 {
+			break
+		}
+		var rings [][]*Point
+		for _, ring := range inset {
+			rings = append(rings, append(append([]*Point{}, ring...), ring[0]))
+		}
+		contours = append(contours, toned(rings, i, spacing, tone)...)
+	}
+	return contours
+}
+
+// ConcentricFill fills a polygon with circles around a center point, spacing apart.
+// If tone is not nil, circles are left out where it is light, as with Hatch.
+func ConcentricFill(polygon Polygon, center *Point, spacing float64, tone ToneFunc) [][]*Point {
+	if spacing <= 0 {
+		return nil
+	}
+	var circles [][]*Point
+	maxRadius := farthestCorner(polygon.Bounds(), center)
+	for i := 0; (float64(i)+0.5)*spacing <= maxRadius; i++ {
+		circle := arcPoints(center, (float64(i)+0.5)*spacing, 0, math.Pi*2)
+		circles = append(circles, toned(ClipPolyline(circle, polygon), i, spacing, tone)...)
+	}
+	return circles
+}
+
+// SpiralFill fills a polygon with a spiral out from a center point, with turns spacing apart.
+// If tone is not nil, parts of turns are left out where it is light, as with Hatch.
+func SpiralFill(polygon Polygon, center *Point, spacing float64, tone ToneFunc) [][]*Point {
+	if spacing <= 0 {
+		return nil
+	}
+	maxRadius := farthestCorner(polygon.Bounds(), center)
+	var turns [][]*Point
+	var turn []*Point
+	for angle := 0.0; ; {
+		r := spacing * angle / (math.Pi * 2)
+		turn = append(turn, NewPoint(center.X+math.Cos(angle)*r, center.Y+math.Sin(angle)*r))
+		if r > maxRadius {
+			break
+		}
+		next := angle + arcStep(math.Max(r, spacing))
+		// with a tone, each turn is toned separately, so split the spiral where turns meet.
+		if tone != nil && math.Floor(next/(math.Pi*2)) > math.Floor(angle/(math.Pi*2)) {
+			next = (math.Floor(angle/(math.Pi*2)) + 1) * math.Pi * 2
+			r = spacing * next / (math.Pi * 2)
+			turns = append(turns, append(turn, NewPoint(center.X+r, center.Y)))
+			turn = nil
+		}
+		angle = next
+	}
+	turns = append(turns, turn)
+	if tone == nil {
+		return ClipPolyline(turns[0], polygon)
+	}
+	var spiral [][]*Point
+	for i, turn := range turns {
+		spiral = append(spiral, toned(ClipPolyline(turn, polygon), i, spacing, tone)...)
+	}
+	return spiral
+}
+
+// ClipPolyline returns the parts of a path that are inside a polygon.
+func ClipPolyline(points []*Point, polygon Polygon) [][]*Point {
+	edges := polygonEdges(polygon)
+	boxes := make([]box, len(edges))
+	for i, e := range edges {
+		boxes[i] = e.box()
+	}
+	var paths [][]*Point
+	var path []*Point
+	for i := 1; i < len(points); i++ {
+		p0, p1 := points[i-1], points[i]
+		segment := &splitEdge{p0: p0, p1: p1}
+		b := segment.box()
+		for j, e := range edges {
+			if b.overlaps(boxes[j]) {
+				// split a copy, as only the segment's crossings are needed.
+				splitPair(segment, &splitEdge{p0: e.p0, p1: e.p1})
+			}
+		}
+		// the segment's points, ordered along it, include any crossings.
+		split := segment.points()
+		for j := 1; j < len(split); j++ {
+			a, b := split[j-1], split[j]
+			if PointInPolygon(LerpPoint(0.5, a, b), polygon) {
+				if len(path) == 0 {
+					path = append(path, a)
+				}
+				path = append(path, b)
+			} else if len(path) > 0 {
+				paths = append(paths, path)
+				path = nil
+			}
+		}
+	}
+	if len(path) > 0 {
+		paths = append(paths, path)
+	}
+	// a closed path inside the polygon where it starts and ends is one path, not two.
+	n := len(paths)
+	if n > 1 && points[0].Distance(points[len(points)-1]) < 1e-12 &&
+		paths[0][0] == points[0] && paths[n-1][len(paths[n-1])-1] == points[len(points)-1] {
+		paths[0] = append(paths[n-1], paths[0][1:]...)
+		paths = paths[:n-1]
+	}
+	return paths
+}
+
+// scanline returns the sorted x positions where a horizontal line crosses a polygon's edges.
+func scanline(polygon Polygon, y float64) []float64 {
+	var xs []float64
+	for _, ring := range polygon {
+		n := len(ring)
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			pi, pj := ring[i], ring[j]
+			if (pi.Y > y) != (pj.Y > y) {
+				xs = append(xs, (pj.X-pi.X)*(y-pi.Y)/(pj.Y-pi.Y)+pi.X)
+			}
+		}
+	}
+	sort.Float64s(xs)
+	return xs
+}
+
+func farthestCorner(bounds *Rectangle, center *Point) float64 {
+	return math.Max(
+		math.Max(Distance(center.X, center.Y, bounds.X, bounds.Y), Distance(center.X, center.Y, bounds.X+bounds.W, bounds.Y)),
+		math.Max(Distance(center.X, center.Y, bounds.X, bounds.Y+bounds.H), Distance(center.X, center.Y, bounds.X+bounds.W, bounds.Y+bounds.H)),
+	)
+}
+
+// toned returns the parts of the paths for a fill line where the tone is dark enough to draw it.
+// Each line's level comes from the van der Corput sequence on its index, so any run of neighboring
+// lines has levels spread evenly between 0.0 and 1.0, and removing the lighter ones
+// leaves the rest evenly spaced.
+func toned(paths [][]*Point, index int, spacing float64, tone ToneFunc) [][]*Point {
+	if tone == nil {
+		return paths
+	}
+	level := float64(bits.Reverse32(uint32(index))) / (1 << 32)
+	var result [][]*Point
+	for _, path := range paths {
+		result = append(result, toneRuns(path, level, spacing/2, tone)...)
+	}
+	return result
+}
+
+// toneRuns returns the parts of a path where the tone is darker than a level, testing it every step along the path.
+func toneRuns(path []*Point, level, step float64, tone ToneFunc) [][]*Point {
+	var runs [][]*Point
+	var run []*Point
+	for i := 1; i < len(path); i++ {
+		p0, p1 := path[i-1], path[i]
+		steps := int(math.Max(1, math.Ceil(p0.Distance(p1)/step)))
+		for j := 0; j < steps; j++ {
+			a := LerpPoint(float64(j)/float64(steps), p0, p1)
+			b := LerpPoint(float64(j+1)/float64(steps), p0, p1)
+			mid := LerpPoint(0.5, a, b)
+			if tone(mid.X, mid.Y) > level {
+				if len(run) == 0 {
+					run = append(run, a)
+				}
+				run = append(run, b)
+			} else if len(run) > 0 {
+				runs = append(runs, SimplifyRDP(run, step*1e-6))
+				run = nil
+			}
+		}
+	}
+	if len(run) > 0 {
+		runs = append(runs, SimplifyRDP(run, step*1e-6))
+	}
+	return runs
+}
@@ -0,0 +1,121 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func totalLength(paths [][]*Point) float64 {
+	length := 0.0
+	for _, path := range paths {
+		length += PathLength(path)
+	}
+	return length
+}
+
+func TestHatch(t *testing.T) {
+	lines := Hatch(square(0, 0, 10), 0, 1, nil)
+	if len(lines) != 10 || math.Abs(totalLength(lines)-100) > 1e-9 {
+		t.Errorf("hatch has %d lines of length %f, want 10 and 100", len(lines), totalLength(lines))
+	}
+
+	lines = Hatch(square(0, 0, 10), math.Pi/4, 1, nil)
+	if math.Abs(totalLength(lines)-100) > 10 {
+		t.Errorf("diagonal hatch length %f, want about 100", totalLength(lines))
+	}
+
+	// half tone leaves every other line.
+	half := func(x, y float64) float64 { return 0.5 }
+	lines = Hatch(square(0, 0, 10), 0, 1, half)
+	if len(lines) != 5 {
+		t.Fatalf("half tone hatch has %d lines, want 5", len(lines))
+	}
+	for i := 1; i < len(lines); i++ {
+		if gap := lines[i][0].Y - lines[i-1][0].Y; math.Abs(gap-2) > 1e-9 {
+			t.Errorf("half tone lines are %f apart, want 2", gap)
+		}
+	}
+
+	// lines stop where the tone is white.
+	left := func(x, y float64) float64 {
+		if x < 5 {
+			return 1
+		}
+		return 0
+	}
+	lines = Hatch(square(0, 0, 10), 0, 1, left)
+	if math.Abs(totalLength(lines)-50) > 1e-9 {
+		t.Errorf("left half hatch length %f, want 50", totalLength(lines))
+	}
+
+	// holes are left empty.
+	frame := PolygonDifference(square(0, 0, 10), square(2.5, 2.5, 5))
+	if length := totalLength(Hatch(frame, 0, 1, nil)); math.Abs(length-75) > 1e-9 {
+		t.Errorf("frame hatch length %f, want 75", length)
+	}
+}
+
+func TestCrossHatch(t *testing.T) {
+	lines := CrossHatch(square(0.5, 0.5, 10), 0, 1, nil)
+	if math.Abs(totalLength(lines)-200) > 1e-9 {
+		t.Errorf("cross hatch length %f, want 200", totalLength(lines))
+	}
+	light := func(x, y float64) float64 { return 0.25 }
+	lines = CrossHatch(square(0.5, 0.5, 10), 0, 1, light)
+	for _, line := range lines {
+		if line[0].Y != line[1].Y {
+			t.Fatalf("light cross hatch has second set lines")
+		}
+	}
+}
+
+func TestContourFill(t *testing.T) {
+	contours := ContourFill(square(0, 0, 10), 2, nil)
+	if len(contours) != 2 {
+		t.Fatalf("contour fill has %d contours, want 2", len(contours))
+	}
+	// contours at 1 and 3 from the edges. at 5 the square has shrunk to nothing.
+	for i, want := range []float64{32, 16} {
+		if length := PathLength(contours[i]); math.Abs(length-want) > 0.1 {
+			t.Errorf("contour %d length %f, want %f", i, length, want)
+		}
+	}
+}
+
+func TestConcentricAndSpiralFill(t *testing.T) {
+	polygon := StarPolygon(50, 50, 20, 50, 5, 0)
+	center := NewPoint(50, 50)
+	for name, paths := range map[string][][]*Point{
+		"concentric": ConcentricFill(polygon, center, 3, nil),
+		"spiral":     SpiralFill(polygon, center, 3, nil),
+	} {
+		if len(paths) == 0 {
+			t.Errorf("%s fill is empty", name)
+		}
+		for _, path := range paths {
+			for i := 1; i < len(path); i++ {
+				mid := LerpPoint(0.5, path[i-1], path[i])
+				if !PointInPolygon(mid, polygon) {
+					t.Fatalf("%s fill has segment outside polygon at %v", name, mid)
+				}
+			}
+		}
+	}
+	if paths := SpiralFill(square(0, 0, 100), NewPoint(50, 50), 5, nil); len(paths) < 2 {
+		t.Errorf("spiral fill of square should be clipped into pieces")
+	}
+}
+
+func TestClipPolyline(t *testing.T) {
+	frame := PolygonDifference(square(0, 0, 10), square(4, 4, 2))
+	paths := ClipPolyline([]*Point{NewPoint(-5, 5), NewPoint(15, 5)}, frame)
+	if len(paths) != 2 || math.Abs(totalLength(paths)-8) > 1e-9 {
+		t.Errorf("clipped line %v, want two pieces of total length 8", paths)
+	}
+
+	// a closed path fully inside stays in one piece.
+	ring := append(square(2, 2, 1)[0], NewPoint(2, 2))
+	if paths := ClipPolyline(ring, square(0, 0, 10)); len(paths) != 1 || len(paths[0]) != 5 {
+		t.Errorf("closed path inside was split into %v", paths)
+	}
+}
@@ -0,0 +1,279 @@
+package geom
+
+import "math"
+
+// JoinStyle is how the corners of offset and stroked paths are shaped.
+type JoinStyle int
+
+const (
+	// JoinMiter extends the sides to a sharp point, falling back to a bevel on very sharp corners.
+	JoinMiter JoinStyle = iota
+	// JoinRound rounds corners with an arc.
+	JoinRound
+	// JoinBevel cuts corners off with a straight line.
+	JoinBevel
+)
+
+// CapStyle is how the ends of stroked open paths are shaped.
+type CapStyle int
+
+const (
+	// CapButt ends a stroke flat at its end points.
+	CapButt CapStyle = iota
+	// CapRound ends a stroke with a half circle.
+	CapRound
+	// CapSquare ends a stroke flat, half the stroke width beyond its end points.
+	CapSquare
+)
+
+// MiterLimit is the longest a miter join can be, relative to the offset distance, before it becomes a bevel.
+var MiterLimit = 4.0
+
+// ArcTolerance is the furthest round joins and caps are allowed to stray from a true arc.
+var ArcTolerance = 0.1
+
+// StrokeOutline returns the outline of a path stroked with the given width, as a polygon.
+func StrokeOutline(points []*Point, width float64, join JoinStyle, cap CapStyle, closed bool) Polygon {
+	return unionAll(strokePieces(points, width/2, join, cap, closed))
+}
+
+// OffsetPolygon grows a polygon outwards by a distance, or shrinks it inwards if the distance is negative.
+// Holes shrink as the polygon grows, and parts of the polygon narrower than twice the distance
+// disappear as it shrinks.
+func OffsetPolygon(polygon Polygon, distance float64, join JoinStyle) Polygon {
+	if distance == 0 {
+		return polygon
+	}
+	var pieces []Polygon
+	for _, ring := range polygon {
+		pieces = append(pieces, strokePieces(ring, math.Abs(distance), join, CapButt, true)...)
+	}
+	if len(pieces) == 0 {
+		return polygon
+	}
+	band := newPolygonIndex(pieces)
+	inside := func(p *Point) bool {
+		if distance > 0 {
+			return PointInPolygon(p, polygon) || band.inAny(p)
+		}
+		return PointInPolygon(p, polygon) && !band.inAny(p)
+	}
+	return resolve(append(pieces, polygon), inside)
+}
+
+// OffsetPolyline returns a path parallel to an open path, at a distance to its left as seen on screen,
+// or to its right if the distance is negative.
+// The path is not cleaned up, so tight inside corners may leave small loops.
+// Use StrokeOutline or OffsetPolygon for a clean result.
+func OffsetPolyline(points []*Point, distance float64, join JoinStyle) []*Point {
+	points = removeDuplicates(points, false)
+	if len(points) < 2 {
+		return nil
+	}
+	n := len(points)
+	nx, ny := segmentNormal(points[0], points[1])
+	result := []*Point{NewPoint(points[0].X+nx*distance, points[0].Y+ny*distance)}
+	for i := 1; i < n-1; i++ {
+		c := newCorner(points[i-1], points[i], points[i+1], distance)
+		joined := c.points(points[i], distance, join)
+		if c.outside && join == JoinMiter && len(joined) == 3 {
+			// only the miter point is needed on a continuous path.
+			joined = joined[1:2]
+		}
+		result = append(result, joined...)
+	}
+	nx, ny = segmentNormal(points[n-2], points[n-1])
+	return append(result, NewPoint(points[n-1].X+nx*distance, points[n-1].Y+ny*distance))
+}
+
+// segmentNormal returns the unit normal to the left of a segment, as seen on screen.
+func segmentNormal(p0, p1 *Point) (float64, float64) {
+	length := p0.Distance(p1)
+	return (p1.Y - p0.Y) / length, (p0.X - p1.X) / length
+}
+
+// corner describes the offset of a path around the corner at p1, on the side given by the sign of distance.
+type corner struct {
+	// a and b are the offset points at the end of the incoming segment and the start of the outgoing one.
+	a, b *Point
+	// miter is where the offset sides would meet if extended.
+	miter *Point
+	// miterRatio is the distance to the miter point relative to the offset distance.
+	miterRatio float64
+	// outside is whether the offset is on the outside of the turn.
+	outside, straight bool
+}
+
+func newCorner(p0, p1, p2 *Point, distance float64) corner {
+	n0x, n0y := segmentNormal(p0, p1)
+	n1x, n1y := segmentNormal(p1, p2)
+	cross := (p1.X-p0.X)*(p2.Y-p1.Y) - (p1.Y-p0.Y)*(p2.X-p1.X)
+	dot := n0x*n1x + n0y*n1y
+	c := corner{
+		a:        NewPoint(p1.X+n0x*distance, p1.Y+n0y*distance),
+		b:        NewPoint(p1.X+n1x*distance, p1.Y+n1y*distance),
+		outside:  (cross > 0) == (distance > 0),
+		straight: dot > 1-1e-9,
+	}
+	if dot > -1+1e-9 {
+		scale := distance / (1 + dot)
+		c.miter = NewPoint(p1.X+(n0x+n1x)*scale, p1.Y+(n0y+n1y)*scale)
+		c.miterRatio = math.Sqrt(2 / (1 + dot))
+	}
+	return c
+}
+
+// points returns the points of the offset path around the corner, from a to b.
+func (c corner) points(center *Point, distance float64, join JoinStyle) []*Point {
+	if c.straight {
+		return []*Point{c.a}
+	}
+	if !c.outside {
+		if c.miter == nil {
+			return []*Point{c.a, c.b}
+		}
+		return []*Point{c.miter}
+	}
+	switch join {
+	case JoinRound:
+		start := math.Atan2(c.a.Y-center.Y, c.a.X-center.X)
+		sweep := math.Atan2(c.b.Y-center.Y, c.b.X-center.X) - start
+		// the outside of a turn is always the short way around.
+		if sweep > math.Pi {
+			sweep -= 2 * math.Pi
+		} else if sweep < -math.Pi {
+			sweep += 2 * math.Pi
+		}
+		return arcPoints(center, math.Abs(distance), start, sweep)
+	case JoinMiter:
+		if c.miter != nil && c.miterRatio <= MiterLimit {
+			return []*Point{c.a, c.miter, c.b}
+		}
+	}
+	return []*Point{c.a, c.b}
+}
+
+// arcPoints returns points along an arc, from a start angle through a sweep angle, which may be negative.
+func arcPoints(center *Point, radius, start, sweep float64) []*Point {
+	steps := int(math.Max(1, math.Ceil(math.Abs(sweep)/arcStep(radius))))
+	points := make([]*Point, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		angle := start + sweep*float64(i)/float64(steps)
+		points = append(points, NewPoint(center.X+math.Cos(angle)*radius, center.Y+math.Sin(angle)*radius))
+	}
+	return points
+}
+
+// arcStep returns the angle between points on an arc of a given radius, keeping within ArcTolerance.
+func arcStep(radius float64) float64 {
+	if radius <= ArcTolerance {
+		return math.Pi / 4
+	}
+	return math.Max(2*math.Acos(1-ArcTolerance/radius), math.Pi/180)
+}
+
+// circleRing returns a closed ring approximating a circle.
+func circleRing(center *Point, radius float64) []*Point {
+	points := arcPoints(center, radius, 0, 2*math.Pi)
+	return points[:len(points)-1]
+}
+
+// removeDuplicates returns a path without consecutive repeated points.
+// On closed paths, an end point repeating the start point is removed too.
+func removeDuplicates(points []*Point, closed bool) []*Point {
+	var result []*Point
+	for _, p := range points {
+		if len(result) == 0 || result[len(result)-1].Distance(p) > 1e-12 {
+			result = append(result, p)
+		}
+	}
+	if closed && len(result) > 1 && result[0].Distance(result[len(result)-1]) <= 1e-12 {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+// strokePieces returns overlapping polygons that together cover a stroked path:
+// a quad for each segment, plus one for each join and cap.
+func strokePieces(points []*Point, halfWidth float64, join JoinStyle, cap CapStyle, closed bool) []Polygon {
+	points = removeDuplicates(points, closed)
+	n := len(points)
+	if n == 0 || halfWidth <= 0 {
+		return nil
+	}
+	if n == 1 {
+		p := points[0]
+		switch cap {
+		case CapRound:
+			return []Polygon{{circleRing(p, halfWidth)}}
+		case CapSquare:
+			return []Polygon{{{
+				NewPoint(p.X-halfWidth, p.Y-halfWidth),
+				NewPoint(p.X+halfWidth, p.Y-halfWidth),
+				NewPoint(p.X+halfWidth, p.Y+halfWidth),
+				NewPoint(p.X-halfWidth, p.Y+halfWidth),
+			}}}
+		}
+		return nil
+	}
+	if closed && n == 2 {
+		closed = false
+	}
+
+	var pieces []Polygon
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	for i := 0; i < segments; i++ {
+		p0, p1 := points[i], points[(i+1)%n]
+		nx, ny := segmentNormal(p0, p1)
+		nx, ny = nx*halfWidth, ny*halfWidth
+		pieces = append(pieces, Polygon{{
+			NewPoint(p0.X+nx, p0.Y+ny),
+			NewPoint(p1.X+nx, p1.Y+ny),
+			NewPoint(p1.X-nx, p1.Y-ny),
+			NewPoint(p0.X-nx, p0.Y-ny),
+		}})
+	}
+
+	for i := 0; i < n; i++ {
+		if !closed && (i == 0 || i == n-1) {
+			continue
+		}
+		p0, p1, p2 := points[(i-1+n)%n], points[i], points[(i+1)%n]
+		if join == JoinRound {
+			pieces = append(pieces, Polygon{circleRing(p1, halfWidth)})
+			continue
+		}
+		// the corner only needs filling on the outside of the turn.
+		cross := (p1.X-p0.X)*(p2.Y-p1.Y) - (p1.Y-p0.Y)*(p2.X-p1.X)
+		distance := halfWidth
+		if cross < 0 {
+			distance = -halfWidth
+		}
+		c := newCorner(p0, p1, p2, distance)
+		if !c.straight {
+			pieces = append(pieces, Polygon{append([]*Point{p1}, c.points(p1, distance, join)...)})
+		}
+	}
+
+	if !closed && cap != CapButt {
+		for _, end := range [][2]*Point{{points[0], points[1]}, {points[n-1], points[n-2]}} {
+			p, next := end[0], end[1]
+			if cap == CapRound {
+				pieces = append(pieces, Polygon{circleRing(p, halfWidth)})
+				continue
+			}
+			length := p.Distance(next)
+			dx, dy := (p.X-next.X)/length*halfWidth, (p.Y-next.Y)/length*halfWidth
+			pieces = append(pieces, Polygon{{
+				NewPoint(p.X-dy, p.Y+dx),
+				NewPoint(p.X+dx-dy, p.Y+dy+dx),
+				NewPoint(p.X+dx+dy, p.Y+dy-dx),
+				NewPoint(p.X+dy, p.Y-dx),
+			}})
+		}
+	}
+	return pieces
+}
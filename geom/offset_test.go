@@ -0,0 +1,90 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOffsetPolygon(t *testing.T) {
+	var tests = []struct {
+		name     string
+		distance float64
+		join     JoinStyle
+		area     float64
+	}{
+		{"grow miter", 10, JoinMiter, 14400},
+		{"grow bevel", 10, JoinBevel, 14200},
+		{"grow round", 10, JoinRound, 14000 + math.Pi*100},
+		{"shrink", -10, JoinMiter, 6400},
+		{"shrink round", -10, JoinRound, 6400},
+	}
+	for _, test := range tests {
+		result := OffsetPolygon(square(0, 0, 100), test.distance, test.join)
+		if len(result) != 1 {
+			t.Errorf("%s has %d rings, want 1", test.name, len(result))
+		}
+		// round joins are approximated with straight segments.
+		if math.Abs(result.Area()-test.area) > 10 {
+			t.Errorf("%s area %f, want %f", test.name, result.Area(), test.area)
+		}
+	}
+
+	if len(OffsetPolygon(square(0, 0, 10), -6, JoinMiter)) != 0 {
+		t.Errorf("square shrunk past its center should be empty")
+	}
+
+	// a hole shrinks as the polygon grows.
+	frame := PolygonDifference(square(0, 0, 10), square(3, 3, 4))
+	result := OffsetPolygon(frame, 1, JoinMiter)
+	if len(result) != 2 || math.Abs(result.Area()-(144-4)) > 1e-6 {
+		t.Errorf("grown frame has %d rings and area %f, want 2 and 140", len(result), result.Area())
+	}
+}
+
+func TestStrokeOutline(t *testing.T) {
+	line := []*Point{NewPoint(0, 0), NewPoint(100, 0)}
+	var tests = []struct {
+		name string
+		cap  CapStyle
+		area float64
+	}{
+		{"butt", CapButt, 2000},
+		{"square", CapSquare, 2400},
+		{"round", CapRound, 2000 + math.Pi*100},
+	}
+	for _, test := range tests {
+		result := StrokeOutline(line, 20, JoinMiter, test.cap, false)
+		if len(result) != 1 || math.Abs(result.Area()-test.area) > 10 {
+			t.Errorf("%s cap has %d rings and area %f, want 1 and %f", test.name, len(result), result.Area(), test.area)
+		}
+	}
+
+	// a closed square stroke is a frame with a hole.
+	ring := square(0, 0, 10)[0]
+	result := StrokeOutline(ring, 2, JoinMiter, CapButt, true)
+	if len(result) != 2 || math.Abs(result.Area()-(144-64)) > 1e-6 {
+		t.Errorf("closed stroke has %d rings and area %f, want 2 and 80", len(result), result.Area())
+	}
+	if PointInPolygon(NewPoint(5, 5), result) {
+		t.Errorf("center of closed stroke is inside")
+	}
+}
+
+func TestOffsetPolyline(t *testing.T) {
+	path := []*Point{NewPoint(0, 0), NewPoint(10, 0), NewPoint(10, 10)}
+	// left of the path, as seen on screen, is outside the corner.
+	result := OffsetPolyline(path, 1, JoinMiter)
+	want := []*Point{NewPoint(0, -1), NewPoint(11, -1), NewPoint(11, 10)}
+	if len(result) != len(want) {
+		t.Fatalf("offset has %d points, want %d", len(result), len(want))
+	}
+	for i, p := range want {
+		if result[i].Distance(p) > 1e-9 {
+			t.Errorf("point %d is %v, want %v", i, result[i], p)
+		}
+	}
+	inside := OffsetPolyline(path, -1, JoinRound)
+	if len(inside) != 3 || inside[1].Distance(NewPoint(9, 1)) > 1e-9 {
+		t.Errorf("inside offset %v, want corner at 9, 1", inside)
+	}
+}
@@ -0,0 +1,170 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/random"
+)
+
+// PoissonTries is how many candidates Poisson disk sampling tries around each point before giving up on it.
+// Fewer is quicker, but leaves more gaps.
+var PoissonTries = 30
+
+// poissonSeedTries is how many random points are tried in each open grid cell when looking for
+// somewhere to start sampling again.
+const poissonSeedTries = 5
+
+// PoissonDisk returns points spread evenly but randomly through a rectangle, no two closer than radius,
+// using Bridson's algorithm. Candidates are drawn from the random package,
+// so the same random.Seed gives the same points.
+func PoissonDisk(bounds *Rectangle, radius float64) []*Point {
+	return poissonDisk(bounds, radius, radius, nil, nil)
+}
+
+// PoissonDiskPolygon returns points spread evenly but randomly through a polygon, no two closer than radius.
+func PoissonDiskPolygon(polygon Polygon, radius float64) []*Point {
+	return poissonDisk(polygon.Bounds(), radius, radius, nil, func(p *Point) bool {
+		return PointInPolygon(p, polygon)
+	})
+}
+
+// PoissonDiskDensity returns points spread randomly through a rectangle, packed closer where the tone is darker.
+// Points are minRadius apart where the tone is 1.0, and maxRadius apart where it is 0.0.
+// The tone can come from noise, or the brightness of an image for stippling.
+func PoissonDiskDensity(bounds *Rectangle, minRadius, maxRadius float64, tone ToneFunc) []*Point {
+	return poissonDisk(bounds, minRadius, maxRadius, func(x, y float64) float64 {
+		t := math.Max(0, math.Min(1, tone(x, y)))
+		return maxRadius + (minRadius-maxRadius)*t
+	}, nil)
+}
+
+// poissonDisk samples points in bounds, keeping each at least radiusAt its position or radiusAt the
+// other point's position from any other, whichever is greater. If radiusAt is nil, points are minRadius apart.
+// If inside is not nil, only points it accepts are kept.
+func poissonDisk(bounds *Rectangle, minRadius, maxRadius float64, radiusAt func(x, y float64) float64, inside func(p *Point) bool) []*Point {
+	if minRadius <= 0 || bounds.W <= 0 || bounds.H <= 0 {
+		return nil
+	}
+	if radiusAt == nil {
+		radiusAt = func(x, y float64) float64 { return minRadius }
+	}
+	// cells small enough that each holds at most one point.
+	cell := minRadius / math.Sqrt2
+	cols := int(math.Ceil(bounds.W / cell))
+	rows := int(math.Ceil(bounds.H / cell))
+	grid := make([]int, cols*rows)
+	for i := range grid {
+		grid[i] = -1
+	}
+	reach := int(math.Ceil(math.Max(minRadius, maxRadius) / cell))
+
+	var points []*Point
+	var radii []float64
+	var active []int
+
+	cellOf := func(p *Point) (int, int) {
+		col := int((p.X - bounds.X) / cell)
+		row := int((p.Y - bounds.Y) / cell)
+		if col >= cols {
+			col = cols - 1
+		}
+		if row >= rows {
+			row = rows - 1
+		}
+		return col, row
+	}
+	fits := func(p *Point, r float64) bool {
+		if !PointInRect(p, bounds.X, bounds.Y, bounds.W, bounds.H) || (inside != nil && !inside(p)) {
+			return false
+		}
+		col, row := cellOf(p)
+		for y := row - reach; y <= row+reach; y++ {
+			for x := col - reach; x <= col+reach; x++ {
+				if x < 0 || x >= cols || y < 0 || y >= rows {
+					continue
+				}
+				if i := grid[x+y*cols]; i >= 0 && p.Distance(points[i]) < math.Max(r, radii[i]) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	add := func(p *Point, r float64) {
+		col, row := cellOf(p)
+		grid[col+row*cols] = len(points)
+		active = append(active, len(points))
+		points = append(points, p)
+		radii = append(radii, r)
+	}
+
+	grow := func() {
+		for len(active) > 0 {
+			k := random.IntRange(0, len(active))
+			p, r := points[active[k]], radii[active[k]]
+			found := false
+			for try := 0; try < PoissonTries; try++ {
+				angle := random.FloatRange(0, math.Pi*2)
+				dist := random.FloatRange(r, r*2)
+				q := NewPoint(p.X+math.Cos(angle)*dist, p.Y+math.Sin(angle)*dist)
+				if rq := radiusAt(q.X, q.Y); fits(q, rq) {
+					add(q, rq)
+					found = true
+					break
+				}
+			}
+			if !found {
+				active[k] = active[len(active)-1]
+				active = active[:len(active)-1]
+			}
+		}
+	}
+
+	// covered returns whether a cell lies wholly within the radius of a point already placed,
+	// so no new point could go there.
+	covered := func(col, row int) bool {
+		x, y := bounds.X+float64(col)*cell, bounds.Y+float64(row)*cell
+		for yy := row - reach; yy <= row+reach; yy++ {
+			for xx := col - reach; xx <= col+reach; xx++ {
+				if xx < 0 || xx >= cols || yy < 0 || yy >= rows {
+					continue
+				}
+				i := grid[xx+yy*cols]
+				if i < 0 {
+					continue
+				}
+				p, r := points[i], radii[i]
+				if math.Hypot(x-p.X, y-p.Y) < r && math.Hypot(x+cell-p.X, y-p.Y) < r &&
+					math.Hypot(x-p.X, y+cell-p.Y) < r && math.Hypot(x+cell-p.X, y+cell-p.Y) < r {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	// seed from every open cell in turn, in a random order, so sampling restarts in each part
+	// of a polygon it has not reached, however small, rather than relying on guesses over the whole bounds.
+	order := make([]int, len(grid))
+	for i := range order {
+		j := random.IntRange(0, i+1)
+		order[i], order[j] = order[j], i
+	}
+	for _, c := range order {
+		col, row := c%cols, c/cols
+		if grid[c] >= 0 || covered(col, row) {
+			continue
+		}
+		x := bounds.X + float64(col)*cell
+		y := bounds.Y + float64(row)*cell
+		for try := 0; try < poissonSeedTries; try++ {
+			p := RandomPoint(x, y, cell, cell)
+			if r := radiusAt(p.X, p.Y); fits(p, r) {
+				add(p, r)
+				grow()
+				break
+			}
+		}
+	}
+	return points
+}
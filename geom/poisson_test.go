@@ -0,0 +1,128 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/bit101/blgo/random"
+)
+
+func closestPair(points []*Point) float64 {
+	min := -1.0
+	for i, p := range points {
+		for _, q := range points[i+1:] {
+			if d := p.Distance(q); min < 0 || d < min {
+				min = d
+			}
+		}
+	}
+	return min
+}
+
+func TestPoissonDisk(t *testing.T) {
+	random.Seed(1)
+	points := PoissonDisk(NewRectangle(10, 20, 100, 50), 5)
+	if d := closestPair(points); d < 5 {
+		t.Errorf("points %f apart, want at least 5", d)
+	}
+	// a maximal packing at radius r has roughly one point per 1.5 to 2 r squared.
+	if len(points) < 5000/50 || len(points) > 5000/25 {
+		t.Errorf("got %d points", len(points))
+	}
+	for _, p := range points {
+		if !PointInRect(p, 10, 20, 100, 50) {
+			t.Errorf("point %v outside bounds", p)
+		}
+	}
+
+	random.Seed(1)
+	again := PoissonDisk(NewRectangle(10, 20, 100, 50), 5)
+	if len(again) != len(points) || again[10].X != points[10].X {
+		t.Errorf("seeding again gave different points")
+	}
+}
+
+func TestPoissonDiskPolygon(t *testing.T) {
+	random.Seed(1)
+	// two separate squares, which sampling must restart to reach.
+	polygon := Polygon{square(0, 0, 20)[0], square(50, 50, 20)[0]}
+	points := PoissonDiskPolygon(polygon, 3)
+	left, right := 0, 0
+	for _, p := range points {
+		switch {
+		case PointInRect(p, 0, 0, 20, 20):
+			left++
+		case PointInRect(p, 50, 50, 20, 20):
+			right++
+		default:
+			t.Errorf("point %v outside polygon", p)
+		}
+	}
+	if left < 20 || right < 20 {
+		t.Errorf("got %d and %d points in the squares", left, right)
+	}
+	if d := closestPair(points); d < 3 {
+		t.Errorf("points %f apart, want at least 3", d)
+	}
+}
+
+func TestPoissonDiskPolygonSparse(t *testing.T) {
+	// a thin diagonal sliver filling little of its bounds.
+	sliver := Polygon{{
+		NewPoint(0, 0), NewPoint(6, 0), NewPoint(500, 494),
+		NewPoint(500, 500), NewPoint(494, 500), NewPoint(0, 6),
+	}}
+	// small squares at opposite corners of a large box.
+	corners := Polygon{square(0, 0, 10)[0], square(490, 490, 10)[0]}
+	for seed := int64(0); seed < 5; seed++ {
+		random.Seed(seed)
+		points := PoissonDiskPolygon(sliver, 3)
+		start, end := 0, 0
+		for _, p := range points {
+			if !PointInPolygon(p, sliver) {
+				t.Errorf("point %v outside sliver", p)
+			}
+			if p.X < 50 {
+				start++
+			} else if p.X > 450 {
+				end++
+			}
+		}
+		if len(points) < 100 || start < 5 || end < 5 {
+			t.Errorf("seed %d: got %d points in the sliver, %d and %d near its ends", seed, len(points), start, end)
+		}
+
+		points = PoissonDiskPolygon(corners, 2)
+		first, second := 0, 0
+		for _, p := range points {
+			if PointInRect(p, 0, 0, 10, 10) {
+				first++
+			} else if PointInRect(p, 490, 490, 10, 10) {
+				second++
+			}
+		}
+		if first < 5 || second < 5 {
+			t.Errorf("seed %d: got %d and %d points in the corner squares", seed, first, second)
+		}
+	}
+}
+
+func TestPoissonDiskDensity(t *testing.T) {
+	random.Seed(1)
+	points := PoissonDiskDensity(NewRectangle(0, 0, 100, 100), 2, 8, func(x, y float64) float64 {
+		return x / 100
+	})
+	left, right := 0, 0
+	for _, p := range points {
+		if p.X < 50 {
+			left++
+		} else {
+			right++
+		}
+	}
+	if right < left*2 {
+		t.Errorf("got %d light and %d dark points, want more dark", left, right)
+	}
+	if d := closestPair(points); d < 2 {
+		t.Errorf("points %f apart, want at least 2", d)
+	}
+}
@@ -0,0 +1,109 @@
+package geom
+
+import "math"
+
+// Polygon is a shape made of one or more closed rings of points.
+// Rings inside other rings are holes, following the even-odd rule.
+// Rings do not need to repeat their first point at the end.
+type Polygon [][]*Point
+
+// PointInRing returns whether or not an x, y point is within a single closed ring of points.
+func PointInRing(x, y float64, ring []*Point) bool {
+	inside := false
+	n := len(ring)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		pi, pj := ring[i], ring[j]
+		if (pi.Y > y) != (pj.Y > y) &&
+			x < (pj.X-pi.X)*(y-pi.Y)/(pj.Y-pi.Y)+pi.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// PointInPolygon returns whether or not a point is within a polygon, using the even-odd rule.
+func PointInPolygon(p *Point, polygon Polygon) bool {
+	inside := false
+	for _, ring := range polygon {
+		if PointInRing(p.X, p.Y, ring) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// RingArea returns the signed area of a closed ring of points.
+// The sign depends on the winding direction of the ring.
+func RingArea(ring []*Point) float64 {
+	area := 0.0
+	n := len(ring)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		area += ring[j].X*ring[i].Y - ring[i].X*ring[j].Y
+	}
+	return area / 2
+}
+
+// Area returns the area of a polygon. Holes are subtracted.
+func (p Polygon) Area() float64 {
+	area := 0.0
+	for _, ring := range p {
+		// a ring nested inside an odd number of other rings is a hole.
+		depth := 0
+		for _, other := range p {
+			if !sameRing(ring, other) && ringInRing(ring, other) {
+				depth++
+			}
+		}
+		if depth%2 == 0 {
+			area += math.Abs(RingArea(ring))
+		} else {
+			area -= math.Abs(RingArea(ring))
+		}
+	}
+	return area
+}
+
+// Bounds returns the smallest rectangle containing all points in the polygon.
+func (p Polygon) Bounds() *Rectangle {
+	var points []*Point
+	for _, ring := range p {
+		points = append(points, ring...)
+	}
+	return BoundingBox(points)
+}
+
+// BoundingBox returns the smallest rectangle containing a list of points.
+func BoundingBox(points []*Point) *Rectangle {
+	if len(points) == 0 {
+		return NewRectangle(0, 0, 0, 0)
+	}
+	minX, minY := math.MaxFloat64, math.MaxFloat64
+	maxX, maxY := -math.MaxFloat64, -math.MaxFloat64
+	for _, p := range points {
+		minX = math.Min(minX, p.X)
+		minY = math.Min(minY, p.Y)
+		maxX = math.Max(maxX, p.X)
+		maxY = math.Max(maxY, p.Y)
+	}
+	return NewRectangle(minX, minY, maxX-minX, maxY-minY)
+}
+
+// ringInRing returns whether or not a ring is inside another, testing the first of its points
+// that isn't on the other ring's edge, as rings may touch.
+func ringInRing(ring, other []*Point) bool {
+	n := len(other)
+	for _, p := range ring {
+		onEdge := false
+		for i := 0; i < n && !onEdge; i++ {
+			onEdge = DistanceToSegment(p, other[i], other[(i+1)%n]) < 1e-9
+		}
+		if !onEdge {
+			return PointInRing(p.X, p.Y, other)
+		}
+	}
+	return false
+}
+
+func sameRing(a, b []*Point) bool {
+	return len(a) > 0 && len(a) == len(b) && &a[0] == &b[0]
+}
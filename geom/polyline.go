@@ -0,0 +1,259 @@
+package geom
+
+import (
+	"container/heap"
+	"math"
+)
+
+// PathLength returns the total length of a path of points.
+func PathLength(points []*Point) float64 {
+	length := 0.0
+	for i := 1; i < len(points); i++ {
+		length += points[i-1].Distance(points[i])
+	}
+	return length
+}
+
+// DistanceToSegment returns the distance from a point to the closest point on a line segment.
+func DistanceToSegment(p, p0, p1 *Point) float64 {
+	dx := p1.X - p0.X
+	dy := p1.Y - p0.Y
+	lengthSQ := dx*dx + dy*dy
+	if lengthSQ == 0 {
+		return p.Distance(p0)
+	}
+	t := ((p.X-p0.X)*dx + (p.Y-p0.Y)*dy) / lengthSQ
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(p0.X+dx*t), p.Y-(p0.Y+dy*t))
+}
+
+// SimplifyRDP reduces the number of points in a path with the Ramer-Douglas-Peucker algorithm.
+// No removed point will be further than epsilon from the simplified path.
+func SimplifyRDP(points []*Point, epsilon float64) []*Point {
+	if len(points) < 3 {
+		return points
+	}
+	keep := make([]bool, len(points))
+	keep[0] = true
+	keep[len(points)-1] = true
+	// iterative, to avoid deep recursion on long paths.
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		first, last := span[0], span[1]
+		maxDist, index := 0.0, -1
+		for i := first + 1; i < last; i++ {
+			d := DistanceToSegment(points[i], points[first], points[last])
+			if d > maxDist {
+				maxDist, index = d, i
+			}
+		}
+		if index >= 0 && maxDist > epsilon {
+			keep[index] = true
+			stack = append(stack, [2]int{first, index}, [2]int{index, last})
+		}
+	}
+	var result []*Point
+	for i, p := range points {
+		if keep[i] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// SimplifyVisvalingam reduces the number of points in a path with the Visvalingam-Whyatt algorithm.
+// Points are removed, smallest first, while the triangle they form with their neighbors
+// has an area less than minArea. The first and last points are always kept.
+func SimplifyVisvalingam(points []*Point, minArea float64) []*Point {
+	if len(points) < 3 {
+		return points
+	}
+	n := len(points)
+	prev := make([]int, n)
+	next := make([]int, n)
+	removed := make([]bool, n)
+	vertices := make([]*vertex, n)
+	h := &vertexHeap{}
+	for i := range points {
+		prev[i] = i - 1
+		next[i] = i + 1
+	}
+	for i := 1; i < n-1; i++ {
+		vertices[i] = &vertex{index: i, area: triangleArea(points[i-1], points[i], points[i+1])}
+		heap.Push(h, vertices[i])
+	}
+	update := func(i int) {
+		if i <= 0 || i >= n-1 {
+			return
+		}
+		vertices[i].area = triangleArea(points[prev[i]], points[i], points[next[i]])
+		heap.Fix(h, vertices[i].heapIndex)
+	}
+	for h.Len() > 0 {
+		v := heap.Pop(h).(*vertex)
+		if v.area >= minArea {
+			break
+		}
+		removed[v.index] = true
+		p, nx := prev[v.index], next[v.index]
+		next[p] = nx
+		prev[nx] = p
+		update(p)
+		update(nx)
+	}
+	var result []*Point
+	for i, p := range points {
+		if !removed[i] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// triangleArea returns the area of the triangle formed by three points.
+func triangleArea(p0, p1, p2 *Point) float64 {
+	return math.Abs((p1.X-p0.X)*(p2.Y-p0.Y)-(p2.X-p0.X)*(p1.Y-p0.Y)) / 2
+}
+
+type vertex struct {
+	index     int
+	area      float64
+	heapIndex int
+}
+
+// vertexHeap is a min heap of vertices by area, for use with container/heap.
+type vertexHeap []*vertex
+
+func (h vertexHeap) Len() int           { return len(h) }
+func (h vertexHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+func (h vertexHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex = i
+	h[j].heapIndex = j
+}
+
+func (h *vertexHeap) Push(x interface{}) {
+	v := x.(*vertex)
+	v.heapIndex = len(*h)
+	*h = append(*h, v)
+}
+
+func (h *vertexHeap) Pop() interface{} {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return v
+}
+
+// Chaikin smooths a path by repeatedly cutting its corners.
+// Each iteration replaces every segment with points at 1/4 and 3/4 along it.
+// Open paths keep their end points.
+func Chaikin(points []*Point, iterations int, closed bool) []*Point {
+	if len(points) < 3 {
+		return points
+	}
+	for iter := 0; iter < iterations; iter++ {
+		n := len(points)
+		var result []*Point
+		count := n - 1
+		if closed {
+			count = n
+		} else {
+			result = append(result, points[0])
+		}
+		for i := 0; i < count; i++ {
+			p0 := points[i]
+			p1 := points[(i+1)%n]
+			result = append(result, LerpPoint(0.25, p0, p1), LerpPoint(0.75, p0, p1))
+		}
+		if !closed {
+			result = append(result, points[n-1])
+		}
+		points = result
+	}
+	return points
+}
+
+// SmoothLaplacian smooths a path by repeatedly moving each point towards the midpoint of its neighbors.
+// Amount is how far to move each iteration, from 0.0 (not at all) to 1.0 (all the way).
+// Open paths keep their end points.
+func SmoothLaplacian(points []*Point, iterations int, amount float64, closed bool) []*Point {
+	n := len(points)
+	result := make([]*Point, n)
+	for i, p := range points {
+		result[i] = NewPoint(p.X, p.Y)
+	}
+	if n < 3 {
+		return result
+	}
+	for iter := 0; iter < iterations; iter++ {
+		current := make([]*Point, n)
+		for i, p := range result {
+			current[i] = NewPoint(p.X, p.Y)
+		}
+		for i := 0; i < n; i++ {
+			if !closed && (i == 0 || i == n-1) {
+				continue
+			}
+			p0 := current[(i-1+n)%n]
+			p1 := current[(i+1)%n]
+			mid := LerpPoint(0.5, p0, p1)
+			result[i] = LerpPoint(amount, current[i], mid)
+		}
+	}
+	return result
+}
+
+// Resample returns points evenly spaced by the given distance along a path.
+// The first point is always included, and the last point is included if it is not already close to the final sample.
+func Resample(points []*Point, spacing float64) []*Point {
+	if len(points) < 2 || spacing <= 0 {
+		return points
+	}
+	result := []*Point{NewPoint(points[0].X, points[0].Y)}
+	// distance along the current segment where the next sample falls.
+	next := spacing
+	for i := 1; i < len(points); i++ {
+		p0, p1 := points[i-1], points[i]
+		length := p0.Distance(p1)
+		for next <= length {
+			result = append(result, LerpPoint(next/length, p0, p1))
+			next += spacing
+		}
+		next -= length
+	}
+	last := points[len(points)-1]
+	if result[len(result)-1].Distance(last) > spacing/2 {
+		result = append(result, NewPoint(last.X, last.Y))
+	}
+	return result
+}
+
+// ResampleCount returns the given number of points evenly spaced along a path, including both ends.
+// A path with no length gives count copies of its point.
+func ResampleCount(points []*Point, count int) []*Point {
+	if len(points) < 2 || count < 2 {
+		return points
+	}
+	last := points[len(points)-1]
+	length := PathLength(points)
+	if length == 0 {
+		result := make([]*Point, count)
+		for i := range result {
+			result[i] = NewPoint(last.X, last.Y)
+		}
+		return result
+	}
+	result := Resample(points, length/float64(count-1))
+	// floating point error can add or drop the last sample.
+	if len(result) > count {
+		result = result[:count]
+	}
+	if len(result) < count {
+		result = append(result, NewPoint(last.X, last.Y))
+	}
+	result[count-1] = NewPoint(last.X, last.Y)
+	return result
+}
@@ -0,0 +1,135 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+// bent returns two noisy straight lines meeting at a corner at 3, 0.
+func bent() []*Point {
+	return []*Point{
+		NewPoint(0, 0),
+		NewPoint(1, 0.01),
+		NewPoint(2, -0.01),
+		NewPoint(3, 0),
+		NewPoint(4, 2.01),
+		NewPoint(5, 3.99),
+		NewPoint(6, 6),
+	}
+}
+
+func TestPathLength(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(3, 4), NewPoint(3, 0)}
+	if PathLength(points) != 9 {
+		t.Errorf("PathLength != 9")
+	}
+}
+
+func TestDistanceToSegment(t *testing.T) {
+	p0 := NewPoint(0, 0)
+	p1 := NewPoint(10, 0)
+	var tests = []struct {
+		x, y float64
+		want float64
+	}{
+		{5, 3, 3},
+		{-3, 4, 5},
+		{13, -4, 5},
+	}
+	for _, test := range tests {
+		result := DistanceToSegment(NewPoint(test.x, test.y), p0, p1)
+		if result != test.want {
+			t.Errorf("DistanceToSegment(%f, %f) = %f, want %f", test.x, test.y, result, test.want)
+		}
+	}
+}
+
+func TestSimplifyRDP(t *testing.T) {
+	result := SimplifyRDP(bent(), 0.1)
+	if len(result) != 3 {
+		t.Fatalf("SimplifyRDP kept %d points, want 3", len(result))
+	}
+	if result[1].X != 3 {
+		t.Errorf("SimplifyRDP removed the corner: %v", result[1])
+	}
+	result = SimplifyRDP(bent(), 0.001)
+	if len(result) != 7 {
+		t.Errorf("SimplifyRDP with small epsilon kept %d points, want 7", len(result))
+	}
+}
+
+func TestSimplifyVisvalingam(t *testing.T) {
+	result := SimplifyVisvalingam(bent(), 0.1)
+	if len(result) != 3 {
+		t.Fatalf("SimplifyVisvalingam kept %d points, want 3", len(result))
+	}
+	if result[1].X != 3 {
+		t.Errorf("SimplifyVisvalingam removed the corner: %v", result[1])
+	}
+}
+
+func TestChaikin(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(4, 0), NewPoint(4, 4)}
+	open := Chaikin(points, 1, false)
+	if len(open) != 6 {
+		t.Errorf("open Chaikin has %d points, want 6", len(open))
+	}
+	if open[0] != points[0] || open[5] != points[2] {
+		t.Errorf("open Chaikin moved end points")
+	}
+	if open[1].X != 1 || open[2].X != 3 {
+		t.Errorf("open Chaikin cut corners in wrong place: %v %v", open[1], open[2])
+	}
+	closed := Chaikin(points, 2, true)
+	if len(closed) != 12 {
+		t.Errorf("closed Chaikin has %d points, want 12", len(closed))
+	}
+}
+
+func TestSmoothLaplacian(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(1, 2), NewPoint(2, 0)}
+	result := SmoothLaplacian(points, 1, 0.5, false)
+	if result[1].Y != 1 {
+		t.Errorf("SmoothLaplacian middle point at %f, want 1", result[1].Y)
+	}
+	if points[1].Y != 2 {
+		t.Errorf("SmoothLaplacian changed the original points")
+	}
+}
+
+func TestResample(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(10, 0), NewPoint(10, 10)}
+	result := Resample(points, 2.5)
+	if len(result) != 9 {
+		t.Fatalf("Resample returned %d points, want 9", len(result))
+	}
+	for i := 1; i < len(result); i++ {
+		d := result[i-1].Distance(result[i])
+		// the corner cuts across, so that spacing is shorter.
+		if i != 4 && math.Abs(d-2.5) > 1e-9 {
+			t.Errorf("Resample spacing %f, want 2.5", d)
+		}
+	}
+
+	counted := ResampleCount(points, 5)
+	if len(counted) != 5 || counted[2].X != 10 || counted[2].Y != 0 || counted[4].Y != 10 {
+		t.Errorf("ResampleCount returned %v", counted)
+	}
+	if counted[4] == points[2] {
+		t.Errorf("ResampleCount reused the end point of the path")
+	}
+
+	same := []*Point{NewPoint(3, 4), NewPoint(3, 4)}
+	counted = ResampleCount(same, 5)
+	if len(counted) != 5 {
+		t.Fatalf("ResampleCount of a zero length path returned %d points, want 5", len(counted))
+	}
+	for _, p := range counted {
+		if p.X != 3 || p.Y != 4 {
+			t.Errorf("ResampleCount of a zero length path returned %v", p)
+		}
+	}
+	if counted[0] == same[0] || counted[1] == same[1] || same[1].X != 3 {
+		t.Errorf("ResampleCount changed or reused the points of a zero length path")
+	}
+}
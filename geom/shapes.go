@@ -0,0 +1,56 @@
+package geom
+
+import "math"
+
+// Polygon returns a polygon approximating the circle, keeping within ArcTolerance.
+func (c *Circle) Polygon() Polygon {
+	return Polygon{circleRing(c.Center, c.Radius)}
+}
+
+// Polygon returns the rectangle as a polygon.
+func (r *Rectangle) Polygon() Polygon {
+	return Polygon{{
+		NewPoint(r.X, r.Y),
+		NewPoint(r.X+r.W, r.Y),
+		NewPoint(r.X+r.W, r.Y+r.H),
+		NewPoint(r.X, r.Y+r.H),
+	}}
+}
+
+// RegularPolygon returns a polygon with equal sides, matching the shape of Surface.Polygon.
+func RegularPolygon(x, y, r float64, sides int, rotation float64) Polygon {
+	var ring []*Point
+	for i := 0; i < sides; i++ {
+		angle := rotation + math.Pi*2/float64(sides)*float64(i)
+		ring = append(ring, NewPoint(x+math.Cos(angle)*r, y+math.Sin(angle)*r))
+	}
+	return Polygon{ring}
+}
+
+// StarPolygon returns a star shaped polygon, matching the shape of Surface.Star.
+func StarPolygon(x, y, r0, r1 float64, points int, rotation float64) Polygon {
+	var ring []*Point
+	for i := 0; i < points*2; i++ {
+		r := r1
+		if i%2 == 1 {
+			r = r0
+		}
+		angle := rotation + math.Pi/float64(points)*float64(i)
+		ring = append(ring, NewPoint(x+math.Cos(angle)*r, y+math.Sin(angle)*r))
+	}
+	return Polygon{ring}
+}
+
+// HeartPolygon returns a heart shaped polygon, matching the shape of Surface.Heart.
+func HeartPolygon(x, y, w, h, r float64) Polygon {
+	var ring []*Point
+	res := math.Sqrt(w * h)
+	cos, sin := math.Cos(r), math.Sin(r)
+	for i := 0; i < int(res); i++ {
+		a := math.Pi * 2 * float64(i) / res
+		px := w * math.Pow(math.Sin(a), 3.0)
+		py := -h * (0.8125*math.Cos(a) - 0.3125*math.Cos(2.0*a) - 0.125*math.Cos(3.0*a) - 0.0625*math.Cos(4.0*a))
+		ring = append(ring, NewPoint(x+px*cos-py*sin, y+px*sin+py*cos))
+	}
+	return Polygon{ring}
+}
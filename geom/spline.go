@@ -0,0 +1,122 @@
+package geom
+
+import "math"
+
+// The spline functions here return bezier paths, as FlattenBezierPath takes:
+// a start point followed by two control points and an end point for each curve.
+// Sample them with BezierPathPoint and BezierPathTangent, or draw them with Surface.BezierPath.
+
+// CatmullRom returns a bezier path for a Catmull-Rom spline, which passes through every point.
+// Alpha sets how the curve is fitted: 0.0 for uniform, 0.5 for centripetal, which never loops
+// or cusps within a curve, and 1.0 for chordal.
+// Tension tightens the curve from 0.0 for a normal Catmull-Rom spline, to 1.0 for straight lines.
+func CatmullRom(points []*Point, alpha, tension float64, closed bool) []*Point {
+	n := len(points)
+	if n < 2 {
+		return points
+	}
+	// neighbor returns the point before or after the ends of an open spline by reflecting the next one in.
+	neighbor := func(i int) *Point {
+		if closed {
+			return points[(i+n)%n]
+		}
+		if i < 0 {
+			return NewPoint(2*points[0].X-points[1].X, 2*points[0].Y-points[1].Y)
+		}
+		if i >= n {
+			return NewPoint(2*points[n-1].X-points[n-2].X, 2*points[n-1].Y-points[n-2].Y)
+		}
+		return points[i]
+	}
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	path := []*Point{points[0]}
+	for i := 0; i < segments; i++ {
+		p0, p1, p2, p3 := neighbor(i-1), neighbor(i), neighbor(i+1), neighbor(i+2)
+		d1 := math.Pow(p0.Distance(p1), alpha)
+		d2 := math.Pow(p1.Distance(p2), alpha)
+		d3 := math.Pow(p2.Distance(p3), alpha)
+		c1 := catmullRomControl(p0, p1, p2, d1, d2)
+		c2 := catmullRomControl(p3, p2, p1, d3, d2)
+		path = append(path, LerpPoint(1-tension, p1, c1), LerpPoint(1-tension, p2, c2), p2)
+	}
+	return path
+}
+
+// catmullRomControl returns the bezier control point next to p1, on a curve from p1 to p2 with p0 before p1.
+// d1 and d2 are the distances from p0 to p1 and p1 to p2, raised to the power of alpha.
+func catmullRomControl(p0, p1, p2 *Point, d1, d2 float64) *Point {
+	if d1 < 1e-12 || d2 < 1e-12 {
+		return p1
+	}
+	a := 2*d1*d1 + 3*d1*d2 + d2*d2
+	b := 3 * d1 * (d1 + d2)
+	return NewPoint(
+		(d1*d1*p2.X-d2*d2*p0.X+a*p1.X)/b,
+		(d1*d1*p2.Y-d2*d2*p0.Y+a*p1.Y)/b,
+	)
+}
+
+// BSpline returns a bezier path for a uniform cubic B-spline, which is pulled towards each point
+// without passing through it. Open splines start and end on their end points.
+func BSpline(points []*Point, closed bool) []*Point {
+	n := len(points)
+	if n < 2 {
+		return points
+	}
+	var controls []*Point
+	if closed {
+		// wrap around far enough to close the curve.
+		controls = append([]*Point{}, points...)
+		for len(controls) < n+3 {
+			controls = append(controls, points[len(controls)%n])
+		}
+	} else {
+		// repeating the end points makes the spline start and end on them.
+		controls = append([]*Point{points[0], points[0]}, points...)
+		controls = append(controls, points[n-1], points[n-1])
+	}
+	var path []*Point
+	for i := 0; i+3 < len(controls); i++ {
+		p0, p1, p2, p3 := controls[i], controls[i+1], controls[i+2], controls[i+3]
+		if i == 0 {
+			path = append(path, NewPoint((p0.X+4*p1.X+p2.X)/6, (p0.Y+4*p1.Y+p2.Y)/6))
+		}
+		path = append(path,
+			NewPoint((2*p1.X+p2.X)/3, (2*p1.Y+p2.Y)/3),
+			NewPoint((p1.X+2*p2.X)/3, (p1.Y+2*p2.Y)/3),
+			NewPoint((p1.X+4*p2.X+p3.X)/6, (p1.Y+4*p2.Y+p3.Y)/6),
+		)
+	}
+	return path
+}
+
+// HermiteSpline returns a bezier path for a cubic Hermite spline, which passes through every point
+// with the given tangent there. Tangents are vectors, and longer tangents pull the curve further along them.
+// Returns nil if there are fewer tangents than points.
+func HermiteSpline(points, tangents []*Point, closed bool) []*Point {
+	n := len(points)
+	if len(tangents) < n {
+		return nil
+	}
+	if n < 2 {
+		return points
+	}
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	path := []*Point{points[0]}
+	for i := 0; i < segments; i++ {
+		p0, p1 := points[i], points[(i+1)%n]
+		m0, m1 := tangents[i], tangents[(i+1)%n]
+		path = append(path,
+			NewPoint(p0.X+m0.X/3, p0.Y+m0.Y/3),
+			NewPoint(p1.X-m1.X/3, p1.Y-m1.Y/3),
+			p1,
+		)
+	}
+	return path
+}
@@ -0,0 +1,88 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func keyframes() []*Point {
+	return []*Point{NewPoint(0, 0), NewPoint(10, 20), NewPoint(30, 20), NewPoint(40, 0)}
+}
+
+func TestCatmullRom(t *testing.T) {
+	points := keyframes()
+	for _, alpha := range []float64{0, 0.5, 1} {
+		for _, closed := range []bool{false, true} {
+			path := CatmullRom(points, alpha, 0, closed)
+			segments := len(points) - 1
+			if closed {
+				segments = len(points)
+			}
+			if len(path) != segments*3+1 {
+				t.Fatalf("path has %d points, want %d", len(path), segments*3+1)
+			}
+			// the curve passes through each point at an equal share of t.
+			for i, p := range points {
+				at := BezierPathPoint(path, float64(i)/float64(segments))
+				if at.Distance(p) > 1e-9 {
+					t.Errorf("alpha %f closed %t: point %d is %v, want %v", alpha, closed, i, at, p)
+				}
+			}
+		}
+	}
+
+	// full tension gives straight lines.
+	path := CatmullRom(points, 0.5, 1, false)
+	if p := BezierPathPoint(path, 1.0/6); p.Distance(NewPoint(5, 10)) > 1e-9 {
+		t.Errorf("tense curve point %v, want 5, 10", p)
+	}
+
+	// the tangent through a point of symmetry is level.
+	path = CatmullRom(points, 0, 0, false)
+	if d := BezierPathTangent(path, 0.5); math.Abs(d.Y) > 1e-9 || d.X <= 0 {
+		t.Errorf("tangent at middle is %v, want level", d)
+	}
+}
+
+func TestBSpline(t *testing.T) {
+	points := keyframes()
+	path := BSpline(points, false)
+	if path[0].Distance(points[0]) > 1e-9 || path[len(path)-1].Distance(points[3]) > 1e-9 {
+		t.Errorf("open B-spline doesn't start and end on its end points")
+	}
+	closed := BSpline(points, true)
+	if len(closed) != len(points)*3+1 || closed[0].Distance(closed[len(closed)-1]) > 1e-9 {
+		t.Errorf("closed B-spline doesn't join up")
+	}
+	// a B-spline stays inside the hull of its points.
+	for i := 0; i <= 100; i++ {
+		p := BezierPathPoint(closed, float64(i)/100)
+		if p.Y < -1e-9 || p.Y > 20+1e-9 {
+			t.Fatalf("point %v is outside the points", p)
+		}
+	}
+}
+
+func TestHermiteSpline(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(10, 0)}
+	tangents := []*Point{NewPoint(0, 30), NewPoint(0, -30)}
+	path := HermiteSpline(points, tangents, false)
+	if d := BezierPathTangent(path, 0); d.Distance(tangents[0]) > 1e-9 {
+		t.Errorf("start tangent %v, want %v", d, tangents[0])
+	}
+	if d := BezierPathTangent(path, 1); d.Distance(tangents[1]) > 1e-9 {
+		t.Errorf("end tangent %v, want %v", d, tangents[1])
+	}
+	if p := BezierPathPoint(path, 1); p.Distance(points[1]) > 1e-9 {
+		t.Errorf("end point %v, want %v", p, points[1])
+	}
+
+	// a missing tangent gives no path, rather than the points as control points.
+	points = append(points, NewPoint(20, 0))
+	if path := HermiteSpline(points, tangents, false); path != nil {
+		t.Errorf("spline with a missing tangent is %v, want nil", path)
+	}
+	if p := BezierPathPoint(nil, 0.5); p != nil {
+		t.Errorf("point along an empty path is %v, want nil", p)
+	}
+}
@@ -0,0 +1,97 @@
+package geom
+
+// Voronoi returns the Voronoi cell of each point, clipped to a rectangle. Each cell is a closed ring around
+// the area closer to its point than to any other. Cells of repeated points, and of points whose cells
+// lie wholly outside the rectangle, are empty.
+func Voronoi(points []*Point, bounds *Rectangle) [][]*Point {
+	extent := BoundingBox(append(points[:len(points):len(points)],
+		NewPoint(bounds.X, bounds.Y), NewPoint(bounds.X+bounds.W, bounds.Y+bounds.H)))
+	return delaunay(points, extent).Voronoi(bounds)
+}
+
+// Voronoi returns the Voronoi cell of each point of the triangulation, clipped to a rectangle.
+// Use the Voronoi function rather than this for a rectangle much larger than the area of the points.
+func (t *Triangulation) Voronoi(bounds *Rectangle) [][]*Point {
+	cells := make([][]*Point, len(t.Points))
+	for i, p := range t.Points {
+		if !t.inserted[i] {
+			continue
+		}
+		cell := []*Point{
+			NewPoint(bounds.X, bounds.Y),
+			NewPoint(bounds.X+bounds.W, bounds.Y),
+			NewPoint(bounds.X+bounds.W, bounds.Y+bounds.H),
+			NewPoint(bounds.X, bounds.Y+bounds.H),
+		}
+		for _, j := range t.neighbors[i] {
+			cell = clipHalfPlane(cell, p, t.Points[j])
+		}
+		if len(cell) > 2 {
+			cells[i] = cell
+		}
+	}
+	return cells
+}
+
+// clipHalfPlane clips a convex ring to the side of the bisector between p and q nearer to p.
+func clipHalfPlane(ring []*Point, p, q *Point) []*Point {
+	mx, my := (p.X+q.X)/2, (p.Y+q.Y)/2
+	nx, ny := q.X-p.X, q.Y-p.Y
+	side := func(a *Point) float64 {
+		return (a.X-mx)*nx + (a.Y-my)*ny
+	}
+	var clipped []*Point
+	n := len(ring)
+	for i, a := range ring {
+		b := ring[(i+1)%n]
+		sa, sb := side(a), side(b)
+		if sa <= 0 {
+			clipped = append(clipped, a)
+		}
+		if (sa < 0 && sb > 0) || (sa > 0 && sb < 0) {
+			clipped = append(clipped, LerpPoint(sa/(sa-sb), a, b))
+		}
+	}
+	return clipped
+}
+
+// RingCentroid returns the center of mass of the area inside a closed ring of points.
+func RingCentroid(ring []*Point) *Point {
+	area := RingArea(ring)
+	n := len(ring)
+	if n == 0 {
+		return NewPoint(0, 0)
+	}
+	if area == 0 {
+		x, y := 0.0, 0.0
+		for _, p := range ring {
+			x += p.X
+			y += p.Y
+		}
+		return NewPoint(x/float64(n), y/float64(n))
+	}
+	x, y := 0.0, 0.0
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		cross := ring[j].X*ring[i].Y - ring[i].X*ring[j].Y
+		x += (ring[j].X + ring[i].X) * cross
+		y += (ring[j].Y + ring[i].Y) * cross
+	}
+	return NewPoint(x/(6*area), y/(6*area))
+}
+
+// Relax spreads points evenly through a rectangle with Lloyd's algorithm, moving each point to the
+// centroid of its Voronoi cell for a number of iterations. It returns the moved points, leaving the originals as they are.
+func Relax(points []*Point, bounds *Rectangle, iterations int) []*Point {
+	relaxed := make([]*Point, len(points))
+	for i, p := range points {
+		relaxed[i] = NewPoint(p.X, p.Y)
+	}
+	for k := 0; k < iterations; k++ {
+		for i, cell := range Voronoi(relaxed, bounds) {
+			if cell != nil {
+				relaxed[i] = RingCentroid(cell)
+			}
+		}
+	}
+	return relaxed
+}
@@ -0,0 +1,64 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVoronoiGrid(t *testing.T) {
+	var points []*Point
+	for y := 0.0; y < 3; y++ {
+		for x := 0.0; x < 3; x++ {
+			points = append(points, NewPoint(x*10+5, y*10+5))
+		}
+	}
+	cells := Voronoi(points, NewRectangle(0, 0, 30, 30))
+	for i, cell := range cells {
+		if math.Abs(math.Abs(RingArea(cell))-100) > 1e-9 {
+			t.Errorf("cell %d has area %f, want 100", i, math.Abs(RingArea(cell)))
+		}
+		c := RingCentroid(cell)
+		if c.Distance(points[i]) > 1e-9 {
+			t.Errorf("cell %d centered on %v, want %v", i, c, points[i])
+		}
+	}
+}
+
+func TestVoronoiCoversBounds(t *testing.T) {
+	points := scattered(200)
+	bounds := NewRectangle(-20, -10, 150, 130)
+	cells := Voronoi(points, bounds)
+	area := 0.0
+	for i, cell := range cells {
+		area += math.Abs(RingArea(cell))
+		if !PointInRing(points[i].X, points[i].Y, cell) {
+			t.Errorf("point %d is outside its cell", i)
+		}
+	}
+	if math.Abs(area-bounds.W*bounds.H) > 1e-6 {
+		t.Errorf("cells cover %f, want %f", area, bounds.W*bounds.H)
+	}
+}
+
+func TestRelax(t *testing.T) {
+	points := scattered(50)
+	bounds := NewRectangle(0, 0, 100, 100)
+	spread := func(points []*Point) float64 {
+		min, max := math.MaxFloat64, 0.0
+		for _, cell := range Voronoi(points, bounds) {
+			area := math.Abs(RingArea(cell))
+			min = math.Min(min, area)
+			max = math.Max(max, area)
+		}
+		return max / min
+	}
+	relaxed := Relax(points, bounds, 20)
+	if spread(relaxed) > spread(points)/4 {
+		t.Errorf("relaxed cells vary by %f, from %f", spread(relaxed), spread(points))
+	}
+	for _, p := range relaxed {
+		if !PointInRect(p, 0, 0, 100, 100) {
+			t.Errorf("relaxed point %v left the bounds", p)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package blgo
+
+import (
+	"image"
+	// registers gif decoding for NewSurfaceFromFile
+	_ "image/gif"
+	"image/jpeg"
+	// registers png decoding for NewSurfaceFromFile
+	_ "image/png"
+	"os"
+)
+
+// NewSurfaceFromImage creates a new Surface containing a copy of the given image.
+func NewSurfaceFromImage(img image.Image) *Surface {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	surface := NewSurface(float64(w), float64(h))
+	surface.Flush()
+	data := surface.GetData()
+	for y := 0; y < h; y++ {
+		index := surface.pixelIndex(0, y)
+		for x := 0; x < w; x++ {
+			// RGBA returns 16 bit, alpha premultiplied values, which is what cairo wants.
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			data[index] = byte(b >> 8)
+			data[index+1] = byte(g >> 8)
+			data[index+2] = byte(r >> 8)
+			data[index+3] = byte(a >> 8)
+			index += 4
+		}
+	}
+	surface.SetData(data)
+	surface.MarkDirty()
+	return surface
+}
+
+// NewSurfaceFromFile creates a new Surface from any image file format registered
+// with the image package. PNG, JPEG and GIF are supported by default.
+func NewSurfaceFromFile(filename string) (*Surface, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewSurfaceFromImage(img), nil
+}
+
+// ToImage returns a copy of the surface as an image with un-premultiplied alpha.
+func (s *Surface) ToImage() *image.NRGBA {
+	w, h := s.GetWidth(), s.GetHeight()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	s.Flush()
+	data := s.GetData()
+	for y := 0; y < h; y++ {
+		index := s.pixelIndex(0, y)
+		pix := img.PixOffset(0, y)
+		for x := 0; x < w; x++ {
+			a := data[index+3]
+			img.Pix[pix] = unpremultiply(data[index+2], a)
+			img.Pix[pix+1] = unpremultiply(data[index+1], a)
+			img.Pix[pix+2] = unpremultiply(data[index], a)
+			img.Pix[pix+3] = a
+			index += 4
+			pix += 4
+		}
+	}
+	return img
+}
+
+// WriteToJPEG writes the surface to a jpeg file with the given quality (1 - 100).
+// JPEG has no alpha channel, so transparent areas will be written as black.
+func (s *Surface) WriteToJPEG(filename string, quality int) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = jpeg.Encode(file, s.ToImage(), &jpeg.Options{Quality: quality})
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// unpremultiply converts a premultiplied channel value to a straight one.
+func unpremultiply(value, alpha byte) byte {
+	if alpha == 0 {
+		return 0
+	}
+	if alpha == 255 {
+		return value
+	}
+	v := (int(value)*255 + int(alpha)/2) / int(alpha)
+	if v > 255 {
+		v = 255
+	}
+	return byte(v)
+}
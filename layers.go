@@ -0,0 +1,217 @@
+package blgo
+
+import (
+	"math"
+
+	cairo "github.com/bit101/go-cairo"
+)
+
+// BlendMode defines how a layer is combined with the layers below it.
+type BlendMode int
+
+const (
+	// BlendNormal draws the layer over the layers below it.
+	BlendNormal BlendMode = iota
+	// BlendMultiply multiplies the layer with the layers below it, darkening them.
+	BlendMultiply
+	// BlendScreen inverts, multiplies and inverts again, lightening the layers below.
+	BlendScreen
+	// BlendOverlay multiplies dark areas and screens light areas of the layers below.
+	BlendOverlay
+	// BlendAdd adds the layer to the layers below it.
+	BlendAdd
+	// BlendDifference subtracts the darker of the two colors from the lighter one.
+	BlendDifference
+	// BlendSoftLight darkens or lightens the layers below, depending on the layer color.
+	BlendSoftLight
+)
+
+// Layer is a single surface in a Layers stack.
+type Layer struct {
+	*Surface
+	Name    string
+	Opacity float64
+	Visible bool
+	Blend   BlendMode
+}
+
+// Layers is a stack of same sized surfaces that can be flattened into a single surface.
+type Layers struct {
+	Width  float64
+	Height float64
+	layers []*Layer
+}
+
+// NewLayers creates a new, empty layer stack.
+func NewLayers(width, height float64) *Layers {
+	return &Layers{
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Add creates a new, transparent layer on top of the stack.
+func (l *Layers) Add(name string) *Layer {
+	layer := &Layer{
+		Surface: NewSurface(l.Width, l.Height),
+		Name:    name,
+		Opacity: 1.0,
+		Visible: true,
+		Blend:   BlendNormal,
+	}
+	l.layers = append(l.layers, layer)
+	return layer
+}
+
+// Get returns the layer with the given name, or nil if there is none.
+func (l *Layers) Get(name string) *Layer {
+	for _, layer := range l.layers {
+		if layer.Name == name {
+			return layer
+		}
+	}
+	return nil
+}
+
+// At returns the layer at the given index, with 0 being the bottom layer.
+func (l *Layers) At(index int) *Layer {
+	return l.layers[index]
+}
+
+// Len returns the number of layers in the stack.
+func (l *Layers) Len() int {
+	return len(l.layers)
+}
+
+// Remove removes the layer with the given name from the stack.
+func (l *Layers) Remove(name string) {
+	for i, layer := range l.layers {
+		if layer.Name == name {
+			l.layers = append(l.layers[:i], l.layers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Move moves the layer with the given name to a new index in the stack.
+func (l *Layers) Move(name string, index int) {
+	layer := l.Get(name)
+	if layer == nil {
+		return
+	}
+	l.Remove(name)
+	if index < 0 {
+		index = 0
+	}
+	if index > len(l.layers) {
+		index = len(l.layers)
+	}
+	l.layers = append(l.layers[:index], append([]*Layer{layer}, l.layers[index:]...)...)
+}
+
+// Flatten blends all visible layers, bottom to top, into a new surface.
+func (l *Layers) Flatten() *Surface {
+	result := NewSurface(l.Width, l.Height)
+	for _, layer := range l.layers {
+		if !layer.Visible || layer.Opacity <= 0 {
+			continue
+		}
+		switch layer.Blend {
+		case BlendNormal:
+			result.paintLayer(layer, cairo.OperatorOver)
+		case BlendAdd:
+			result.paintLayer(layer, cairo.OperatorAdd)
+		default:
+			result.blendLayer(layer)
+		}
+	}
+	return result
+}
+
+// WriteToPNG flattens the layers and writes the result to a png file.
+func (l *Layers) WriteToPNG(filename string) cairo.Status {
+	return l.Flatten().WriteToPNG(filename)
+}
+
+// paintLayer paints a layer onto this surface using a cairo operator.
+func (s *Surface) paintLayer(layer *Layer, operator cairo.Operator) {
+	s.Save()
+	s.IdentityMatrix()
+	s.SetOperator(operator)
+	s.SetSourceSurface(&layer.Surface.Surface, 0, 0)
+	s.PaintWithAlpha(layer.Opacity)
+	s.Restore()
+}
+
+// blendLayer blends a layer onto this surface in software, for blend modes cairo doesn't provide.
+func (s *Surface) blendLayer(layer *Layer) {
+	blend := blendFuncs[layer.Blend]
+	opacity := math.Min(layer.Opacity, 1)
+	s.Flush()
+	layer.Flush()
+	dst := s.GetData()
+	src := layer.GetData()
+	w, h := s.GetWidth(), s.GetHeight()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := s.pixelIndex(x, y)
+			j := layer.pixelIndex(x, y)
+			as := float64(src[j+3]) / 255.0 * opacity
+			if as == 0 {
+				continue
+			}
+			ab := float64(dst[i+3]) / 255.0
+			ao := as + ab*(1-as)
+			for c := 0; c < 3; c++ {
+				// straight colors
+				cs := float64(src[j+c]) / float64(src[j+3])
+				cb := 0.0
+				if dst[i+3] > 0 {
+					cb = float64(dst[i+c]) / float64(dst[i+3])
+				}
+				// W3C compositing: mix the blended color by backdrop alpha, then source over.
+				co := as*(1-ab)*cs + as*ab*blend(cb, cs) + (1-as)*ab*cb
+				dst[i+c] = toByte(math.Min(co, ao))
+			}
+			dst[i+3] = toByte(ao)
+		}
+	}
+	s.SetData(dst)
+	s.MarkDirty()
+}
+
+// blendFuncs holds the separable blend functions for the software blend modes.
+// Each takes a backdrop and source channel value from 0.0 to 1.0.
+var blendFuncs = map[BlendMode]func(cb, cs float64) float64{
+	BlendMultiply: func(cb, cs float64) float64 {
+		return cb * cs
+	},
+	BlendScreen: screen,
+	BlendOverlay: func(cb, cs float64) float64 {
+		return hardLight(cs, cb)
+	},
+	BlendDifference: func(cb, cs float64) float64 {
+		return math.Abs(cb - cs)
+	},
+	BlendSoftLight: func(cb, cs float64) float64 {
+		if cs <= 0.5 {
+			return cb - (1-2*cs)*cb*(1-cb)
+		}
+		d := math.Sqrt(cb)
+		if cb <= 0.25 {
+			d = ((16*cb-12)*cb + 4) * cb
+		}
+		return cb + (2*cs-1)*(d-cb)
+	},
+}
+
+func screen(cb, cs float64) float64 {
+	return cb + cs - cb*cs
+}
+
+func hardLight(cb, cs float64) float64 {
+	if cs <= 0.5 {
+		return cb * 2 * cs
+	}
+	return screen(cb, 2*cs-1)
+}
@@ -51,22 +51,25 @@ func (d *LogDisplay) Get(x, y int) float64 {
 }
 
 func (d *LogDisplay) RenderGrey() {
-	d.Render(color.Grey)
+	d.render(color.Grey, 0)
 }
 
 func (d *LogDisplay) RenderHSV(min, max float64) {
-	d.Render(func(g float64) color.Color {
+	d.render(func(g float64) color.Color {
 		h := g*(max-min) + min
 		return color.HSV(h, 1, g)
-	})
+	}, 0)
 }
 
+// Render colors each pixel with the color returned by colorFunc for its logarithmic value.
+// colorFunc is called from a single goroutine, so it need not be safe for concurrent use.
 func (d *LogDisplay) Render(colorFunc func(float64) color.Color) {
-	for x := 0; x < d.width; x++ {
-		for y := 0; y < d.height; y++ {
-			g := d.Get(x, y)
-			d.surface.SetSourceColor(colorFunc(g))
-			d.surface.FillRectangle(float64(x), float64(y), 1, 1)
-		}
-	}
+	d.render(colorFunc, 1)
+}
+
+// render shades the surface with colorFunc using the given number of workers, or one per cpu if 0.
+func (d *LogDisplay) render(colorFunc func(float64) color.Color, workers int) {
+	d.surface.Shade(func(x, y float64) color.Color {
+		return colorFunc(d.Get(int(x), int(y)))
+	}, &blgo.ShadeOptions{Workers: workers})
 }
@@ -0,0 +1,176 @@
+package lsystem
+
+import (
+	"math"
+	"strings"
+
+	"github.com/bit101/blgo"
+	"github.com/bit101/blgo/color"
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/turtle"
+)
+
+// LSystem is an axiom and rules to grow words from it, with settings for drawing them.
+//
+// Words are drawn with a turtle, reading these symbols:
+//
+//	DrawSymbols  move forward drawing a line
+//	MoveSymbols  move forward without drawing
+//	+ -          turn left or right by the angle
+//	|            turn around
+//	[ ]          push and pop the turtle's state, to draw a branch
+//	> <          multiply or divide the length by LengthScale
+//	* /          multiply or divide the angle by AngleScale
+//	!            multiply the line width by WidthScale
+//
+// A parameter on any of these is used in place of the length, angle or scale.
+// Other symbols are ignored when drawing.
+type LSystem struct {
+	Axiom string
+	Rules []*Rule
+	// Ignore lists symbols skipped when matching the context of rules, such as "+-F".
+	Ignore      string
+	DrawSymbols string
+	MoveSymbols string
+	// Angle and Heading are in degrees. A heading of -90 starts by drawing up the screen.
+	Angle       float64
+	Heading     float64
+	Length      float64
+	Width       float64
+	LengthScale float64
+	AngleScale  float64
+	WidthScale  float64
+	Color       color.Color
+}
+
+// NewLSystem creates a new L-system, turning by an angle in degrees,
+// drawing with F and G, moving with f, and starting up the screen.
+func NewLSystem(axiom string, angle float64, rules ...*Rule) *LSystem {
+	return &LSystem{
+		Axiom:       axiom,
+		Rules:       rules,
+		DrawSymbols: "FG",
+		MoveSymbols: "f",
+		Angle:       angle,
+		Heading:     -90,
+		Length:      10,
+		Width:       1,
+		LengthScale: 0.7,
+		AngleScale:  0.9,
+		WidthScale:  0.7,
+		Color:       color.Black(),
+	}
+}
+
+type drawState struct {
+	length, angle, width float64
+}
+
+// Interpret draws a word with a turtle, from its current position and heading.
+// It sets the turtle to use degrees.
+func (l *LSystem) Interpret(word Word, t *turtle.Turtle) {
+	t.SetDegrees(true)
+	t.SetColor(l.Color)
+	t.SetWidth(l.Width)
+	current := drawState{l.Length, l.Angle, l.Width}
+	var stack []drawState
+	for _, module := range word {
+		param := func(value float64) float64 {
+			if len(module.Params) > 0 {
+				return module.Params[0]
+			}
+			return value
+		}
+		switch {
+		case strings.ContainsRune(l.DrawSymbols, module.Symbol):
+			t.Forward(param(current.length))
+		case strings.ContainsRune(l.MoveSymbols, module.Symbol):
+			down := t.IsPenDown()
+			t.PenUp()
+			t.Forward(param(current.length))
+			if down {
+				t.PenDown()
+			}
+		case module.Symbol == '+':
+			t.Left(param(current.angle))
+		case module.Symbol == '-':
+			t.Right(param(current.angle))
+		case module.Symbol == '|':
+			t.Right(180)
+		case module.Symbol == '[':
+			stack = append(stack, current)
+			t.Push()
+		case module.Symbol == ']':
+			if len(stack) > 0 {
+				current = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+			t.Pop()
+		case module.Symbol == '>':
+			current.length *= param(l.LengthScale)
+		case module.Symbol == '<':
+			current.length /= param(l.LengthScale)
+		case module.Symbol == '*':
+			current.angle *= param(l.AngleScale)
+		case module.Symbol == '/':
+			current.angle /= param(l.AngleScale)
+		case module.Symbol == '!':
+			current.width *= param(l.WidthScale)
+			t.SetWidth(current.width)
+		}
+	}
+	t.Finish()
+}
+
+// Lines returns the lines drawn by the word grown over a number of generations, starting at 0, 0.
+func (l *LSystem) Lines(generations int) []*turtle.Line {
+	t := turtle.NewTurtle(nil, 0, 0)
+	t.SetDegrees(true)
+	t.SetHeading(l.Heading)
+	l.Interpret(l.Generate(generations), t)
+	return t.Lines()
+}
+
+// FitLines returns the lines drawn by the word grown over a number of generations,
+// scaled and centered to fit a rectangle, inset by a margin. Line widths are not scaled.
+func (l *LSystem) FitLines(generations int, bounds *geom.Rectangle, margin float64) []*turtle.Line {
+	return Fit(l.Lines(generations), bounds, margin)
+}
+
+// Draw grows the word over a number of generations and strokes it onto a surface, fitted to its bounds.
+func (l *LSystem) Draw(surface *blgo.Surface, generations int, margin float64) {
+	turtle.DrawLines(surface, l.FitLines(generations, surface.GetBounds(), margin))
+}
+
+// Fit returns copies of lines, scaled and centered to fit a rectangle, inset by a margin.
+func Fit(lines []*turtle.Line, bounds *geom.Rectangle, margin float64) []*turtle.Line {
+	var points []*geom.Point
+	for _, line := range lines {
+		points = append(points, line.Points...)
+	}
+	box := geom.BoundingBox(points)
+	w, h := bounds.W-margin*2, bounds.H-margin*2
+	scale := 1.0
+	switch {
+	case box.W > 0 && box.H > 0:
+		scale = math.Min(w/box.W, h/box.H)
+	case box.W > 0:
+		scale = w / box.W
+	case box.H > 0:
+		scale = h / box.H
+	}
+	cx, cy := bounds.X+bounds.W/2, bounds.Y+bounds.H/2
+	bx, by := box.X+box.W/2, box.Y+box.H/2
+	fitted := make([]*turtle.Line, len(lines))
+	for i, line := range lines {
+		fitted[i] = &turtle.Line{
+			Points: make([]*geom.Point, len(line.Points)),
+			Color:  line.Color,
+			Width:  line.Width,
+		}
+		for j, p := range line.Points {
+			fitted[i].Points[j] = geom.NewPoint(cx+(p.X-bx)*scale, cy+(p.Y-by)*scale)
+		}
+	}
+	return fitted
+}
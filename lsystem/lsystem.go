@@ -0,0 +1,233 @@
+// Package lsystem grows Lindenmayer systems and draws them with a turtle.
+package lsystem
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bit101/blgo/random"
+)
+
+// Module is a symbol in an L-system word, with any parameters it carries.
+type Module struct {
+	Symbol rune
+	Params []float64
+}
+
+// M creates a module, for building successors in parametric rules.
+func M(symbol rune, params ...float64) Module {
+	return Module{symbol, params}
+}
+
+// Word is a string of modules.
+type Word []Module
+
+// Parse reads a word from a string, where each character is a symbol
+// and parameters follow in parentheses, as in "F(10)+(45)A(1,0.5)".
+// Parameters that are not numbers are read as 0.
+func Parse(s string) Word {
+	var word Word
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		module := Module{Symbol: runes[i]}
+		if i+1 < len(runes) && runes[i+1] == '(' {
+			end := i + 2
+			for end < len(runes) && runes[end] != ')' {
+				end++
+			}
+			for _, param := range strings.Split(string(runes[i+2:end]), ",") {
+				value, _ := strconv.ParseFloat(strings.TrimSpace(param), 64)
+				module.Params = append(module.Params, value)
+			}
+			i = end
+		}
+		word = append(word, module)
+	}
+	return word
+}
+
+// String returns the word as Parse reads it.
+func (w Word) String() string {
+	var b strings.Builder
+	for _, module := range w {
+		b.WriteRune(module.Symbol)
+		if len(module.Params) > 0 {
+			b.WriteRune('(')
+			for i, param := range module.Params {
+				if i > 0 {
+					b.WriteRune(',')
+				}
+				b.WriteString(strconv.FormatFloat(param, 'g', -1, 64))
+			}
+			b.WriteRune(')')
+		}
+	}
+	return b.String()
+}
+
+// Rule is a production, replacing a symbol with a successor.
+// Left and Right, if not 0, are the symbols that must come before and after it for the rule to apply.
+// Condition, if not nil, must return true for the symbol's parameters.
+// When several rules apply, one is picked at random by Weight, using the random package.
+type Rule struct {
+	Symbol      rune
+	Left, Right rune
+	Condition   func(params []float64) bool
+	Weight      float64
+	Produce     func(params []float64) Word
+}
+
+// NewRule creates a rule replacing a symbol with a successor word.
+func NewRule(symbol rune, successor string) *Rule {
+	return NewStochasticRule(symbol, 1, successor)
+}
+
+// NewStochasticRule creates a rule replacing a symbol with a successor word,
+// picked by weight from among the other rules for the same symbol.
+func NewStochasticRule(symbol rune, weight float64, successor string) *Rule {
+	word := Parse(successor)
+	return &Rule{
+		Symbol: symbol,
+		Weight: weight,
+		Produce: func(params []float64) Word {
+			return word
+		},
+	}
+}
+
+// NewContextRule creates a rule replacing a symbol with a successor word,
+// only where it comes after the left symbol and before the right one. Either can be 0 to match anything.
+func NewContextRule(left, symbol, right rune, successor string) *Rule {
+	rule := NewRule(symbol, successor)
+	rule.Left = left
+	rule.Right = right
+	return rule
+}
+
+// NewParametricRule creates a rule replacing a symbol with a word made from its parameters,
+// if the condition is nil or true for them.
+func NewParametricRule(symbol rune, condition func(params []float64) bool, produce func(params []float64) Word) *Rule {
+	return &Rule{
+		Symbol:    symbol,
+		Condition: condition,
+		Weight:    1,
+		Produce:   produce,
+	}
+}
+
+// Generate returns the word grown from the axiom over a number of generations.
+func (l *LSystem) Generate(generations int) Word {
+	word := Parse(l.Axiom)
+	for i := 0; i < generations; i++ {
+		word = l.Step(word)
+	}
+	return word
+}
+
+// Step applies the rules once to every module of a word, leaving modules with no rule unchanged.
+func (l *LSystem) Step(word Word) Word {
+	var next Word
+	for i, module := range word {
+		rule := l.pick(word, i)
+		if rule == nil {
+			next = append(next, module)
+			continue
+		}
+		next = append(next, rule.Produce(module.Params)...)
+	}
+	return next
+}
+
+// pick returns the rule to apply to the module at index i, or nil if there is none.
+// Rules with context take precedence over rules without.
+func (l *LSystem) pick(word Word, i int) *Rule {
+	var left, right rune
+	var matches []*Rule
+	found, contextual := false, false
+	for _, rule := range l.Rules {
+		if rule.Symbol != word[i].Symbol {
+			continue
+		}
+		if rule.Left != 0 || rule.Right != 0 {
+			if !found {
+				left, right = l.leftContext(word, i), l.rightContext(word, i)
+				found = true
+			}
+			if (rule.Left != 0 && rule.Left != left) || (rule.Right != 0 && rule.Right != right) {
+				continue
+			}
+		}
+		if rule.Condition != nil && !rule.Condition(word[i].Params) {
+			continue
+		}
+		hasContext := rule.Left != 0 || rule.Right != 0
+		if hasContext && !contextual {
+			matches = nil
+			contextual = true
+		}
+		if hasContext == contextual {
+			matches = append(matches, rule)
+		}
+	}
+	if len(matches) < 2 {
+		if len(matches) == 0 {
+			return nil
+		}
+		return matches[0]
+	}
+	total := 0.0
+	for _, rule := range matches {
+		total += rule.Weight
+	}
+	r := random.Float() * total
+	for _, rule := range matches {
+		r -= rule.Weight
+		if r < 0 {
+			return rule
+		}
+	}
+	return matches[len(matches)-1]
+}
+
+// leftContext returns the symbol before the module at index i, skipping ignored symbols and
+// branches, and stepping out of the branch the module is in. It returns 0 if there is none.
+func (l *LSystem) leftContext(word Word, i int) rune {
+	depth := 0
+	for j := i - 1; j >= 0; j-- {
+		symbol := word[j].Symbol
+		switch {
+		case symbol == ']':
+			depth++
+		case symbol == '[':
+			if depth > 0 {
+				depth--
+			}
+		case depth > 0 || strings.ContainsRune(l.Ignore, symbol):
+		default:
+			return symbol
+		}
+	}
+	return 0
+}
+
+// rightContext returns the symbol after the module at index i, skipping ignored symbols and
+// branches. It returns 0 if the module ends its branch.
+func (l *LSystem) rightContext(word Word, i int) rune {
+	depth := 0
+	for j := i + 1; j < len(word); j++ {
+		symbol := word[j].Symbol
+		switch {
+		case symbol == '[':
+			depth++
+		case symbol == ']':
+			if depth == 0 {
+				return 0
+			}
+			depth--
+		case depth > 0 || strings.ContainsRune(l.Ignore, symbol):
+		default:
+			return symbol
+		}
+	}
+	return 0
+}
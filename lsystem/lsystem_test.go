@@ -0,0 +1,108 @@
+package lsystem
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/random"
+)
+
+func TestParse(t *testing.T) {
+	word := Parse("F(10)+A(1,0.5)[f]")
+	if len(word) != 6 {
+		t.Fatalf("parsed %d modules, want 6", len(word))
+	}
+	if word[2].Symbol != 'A' || len(word[2].Params) != 2 || word[2].Params[1] != 0.5 {
+		t.Errorf("module %v, want A(1,0.5)", word[2])
+	}
+	if s := word.String(); s != "F(10)+A(1,0.5)[f]" {
+		t.Errorf("string %q, want F(10)+A(1,0.5)[f]", s)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	l := NewLSystem("A", 0, NewRule('A', "AB"), NewRule('B', "A"))
+	if s := l.Generate(4).String(); s != "ABAABABA" {
+		t.Errorf("got %s, want ABAABABA", s)
+	}
+}
+
+func TestContextRule(t *testing.T) {
+	// a signal passing along a chain, skipping over branches.
+	l := NewLSystem("BA[A]AAA", 0,
+		NewContextRule('B', 'A', 0, "B"),
+		NewRule('B', "A"),
+	)
+	l.Ignore = "+-"
+	want := []string{"AB[A]AAA", "AA[B]BAA", "AA[A]ABA", "AA[A]AAB"}
+	word := l.Generate(0)
+	for _, w := range want {
+		word = l.Step(word)
+		if word.String() != w {
+			t.Errorf("got %s, want %s", word, w)
+		}
+	}
+}
+
+func TestStochasticRule(t *testing.T) {
+	random.Seed(1)
+	l := NewLSystem("AAAAAAAAAAAAAAAAAAAA", 0,
+		NewStochasticRule('A', 1, "B"),
+		NewStochasticRule('A', 3, "C"),
+	)
+	counts := map[rune]int{}
+	for _, module := range l.Generate(1) {
+		counts[module.Symbol]++
+	}
+	if counts['B'] == 0 || counts['C'] <= counts['B'] {
+		t.Errorf("picked %d B and %d C, want more C than B", counts['B'], counts['C'])
+	}
+}
+
+func TestParametricRule(t *testing.T) {
+	l := NewLSystem("A(4)", 0,
+		NewParametricRule('A', func(params []float64) bool {
+			return params[0] > 1
+		}, func(params []float64) Word {
+			return Word{M('F', params[0]), M('A', params[0]/2)}
+		}),
+	)
+	if s := l.Generate(5).String(); s != "F(4)F(2)A(1)" {
+		t.Errorf("got %s, want F(4)F(2)A(1)", s)
+	}
+}
+
+func TestLines(t *testing.T) {
+	l := NewLSystem("F+F[-F]F", 90)
+	l.Heading = 0
+	lines := l.Lines(0)
+	if len(lines) != 2 {
+		t.Fatalf("drew %d lines, want 2", len(lines))
+	}
+	// the first line carries on into the branch, which turns back right.
+	end := lines[0].Points[len(lines[0].Points)-1]
+	if math.Abs(end.X-20) > 1e-9 || math.Abs(end.Y+10) > 1e-9 {
+		t.Errorf("branch ends at %v, want 20, -10", end)
+	}
+	// after the branch, the stem carries on up from where it left off.
+	start, last := lines[1].Points[0], lines[1].Points[1]
+	if math.Abs(start.X-10) > 1e-9 || math.Abs(start.Y+10) > 1e-9 || math.Abs(last.Y+20) > 1e-9 {
+		t.Errorf("stem runs from %v to %v, want 10, -10 to 10, -20", start, last)
+	}
+}
+
+func TestFit(t *testing.T) {
+	lines := KochSnowflake().FitLines(3, geom.NewRectangle(0, 0, 200, 100), 10)
+	var points []*geom.Point
+	for _, line := range lines {
+		points = append(points, line.Points...)
+	}
+	box := geom.BoundingBox(points)
+	if math.Abs(box.H-80) > 1e-9 || box.W > 180 {
+		t.Errorf("fitted to %v, want 80 high and at most 180 wide", box)
+	}
+	if math.Abs(box.X+box.W/2-100) > 1e-9 || math.Abs(box.Y+box.H/2-50) > 1e-9 {
+		t.Errorf("fitted to %v, want centered on 100, 50", box)
+	}
+}
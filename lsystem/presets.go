@@ -0,0 +1,84 @@
+package lsystem
+
+// KochCurve returns the Koch curve, drawn across the screen.
+func KochCurve() *LSystem {
+	l := NewLSystem("F", 60, NewRule('F', "F+F--F+F"))
+	l.Heading = 0
+	return l
+}
+
+// KochSnowflake returns the Koch snowflake.
+func KochSnowflake() *LSystem {
+	l := NewLSystem("F--F--F", 60, NewRule('F', "F+F--F+F"))
+	l.Heading = 0
+	return l
+}
+
+// SierpinskiTriangle returns the Sierpinski triangle.
+func SierpinskiTriangle() *LSystem {
+	l := NewLSystem("F-G-G", 120,
+		NewRule('F', "F-G+F+G-F"),
+		NewRule('G', "GG"),
+	)
+	l.Heading = 0
+	return l
+}
+
+// SierpinskiArrowhead returns the Sierpinski arrowhead curve, which fills the Sierpinski triangle with a single line.
+func SierpinskiArrowhead() *LSystem {
+	l := NewLSystem("A", 60,
+		NewRule('A', "B-A-B"),
+		NewRule('B', "A+B+A"),
+	)
+	l.DrawSymbols = "AB"
+	l.Heading = 0
+	return l
+}
+
+// DragonCurve returns the Heighway dragon curve.
+func DragonCurve() *LSystem {
+	l := NewLSystem("FX", 90,
+		NewRule('X', "X+YF+"),
+		NewRule('Y', "-FX-Y"),
+	)
+	l.Heading = 0
+	return l
+}
+
+// Plant returns a fractal plant with curving, branching fronds.
+func Plant() *LSystem {
+	return NewLSystem("X", 25,
+		NewRule('X', "F+[[X]-X]-F[-FX]+X"),
+		NewRule('F', "FF"),
+	)
+}
+
+// Weed returns a weed with branches growing from either side of each stem.
+func Weed() *LSystem {
+	return NewLSystem("F", 25.7, NewRule('F', "F[+F]F[-F]F"))
+}
+
+// Bush returns a stochastic plant, which grows differently each time, as picked by the random package.
+func Bush() *LSystem {
+	return NewLSystem("F", 25.7,
+		NewStochasticRule('F', 1, "F[+F]F[-F]F"),
+		NewStochasticRule('F', 1, "F[+F]F"),
+		NewStochasticRule('F', 1, "F[-F]F"),
+	)
+}
+
+// Tree returns a parametric tree, with each branch shorter and thinner than its trunk.
+func Tree() *LSystem {
+	l := NewLSystem("A(100)", 30,
+		NewParametricRule('A', nil, func(params []float64) Word {
+			s := params[0]
+			return Word{
+				M('F', s), M('!'),
+				M('['), M('+'), M('A', s*0.7), M(']'),
+				M('['), M('-'), M('A', s*0.7), M(']'),
+			}
+		}),
+	)
+	l.Width = 8
+	return l
+}
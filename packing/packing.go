@@ -0,0 +1,257 @@
+// Package packing fills regions with circles and other shapes that don't overlap.
+// Positions, radii and angles come from the random package, so a packing can be repeated
+// by calling random.Seed before it.
+package packing
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/random"
+)
+
+// Packer packs circles into a region.
+type Packer struct {
+	Region               Region
+	MinRadius, MaxRadius float64
+	// Padding is the gap left between shapes.
+	Padding float64
+	// Attempts is how many places in a row Grow tries without fitting a shape before it stops.
+	Attempts int
+	// Density, if not nil, sets the size of shapes across the region: MaxRadius where it is 0.0,
+	// down to MinRadius where it is 1.0.
+	Density geom.ToneFunc
+}
+
+// NewPacker creates a new packer, for shapes with radii from minRadius to maxRadius.
+func NewPacker(region Region, minRadius, maxRadius float64) *Packer {
+	return &Packer{
+		Region:    region,
+		MinRadius: minRadius,
+		MaxRadius: maxRadius,
+		Attempts:  500,
+	}
+}
+
+// radiusAt returns the largest radius a shape can have at a point, according to the density.
+func (p *Packer) radiusAt(x, y float64) float64 {
+	if p.Density == nil {
+		return p.MaxRadius
+	}
+	t := math.Max(0, math.Min(1, p.Density(x, y)))
+	return p.MaxRadius + (p.MinRadius-p.MaxRadius)*t
+}
+
+// Grow packs circles by placing each at a random point and growing it until it touches the edge of the region
+// or another circle, or reaches the largest radius allowed there. Circles smaller than MinRadius are left out.
+// Circles placed early on have the most room, and later ones fill the gaps between them.
+func (p *Packer) Grow() []*geom.Circle {
+	bounds := p.Region.Bounds()
+	g := newGrid(bounds, p.MaxRadius*2+p.Padding)
+	var circles []*geom.Circle
+	for fails := 0; fails < p.Attempts; {
+		c := geom.RandomPoint(bounds.X, bounds.Y, bounds.W, bounds.H)
+		r := -1.0
+		if p.Region.Contains(c.X, c.Y) {
+			r = math.Min(p.radiusAt(c.X, c.Y), p.Region.EdgeDistance(c.X, c.Y))
+			g.each(c.X, c.Y, func(i int) {
+				other := circles[i]
+				r = math.Min(r, c.Distance(other.Center)-other.Radius-p.Padding)
+			})
+		}
+		if r < p.MinRadius || r <= 0 {
+			fails++
+			continue
+		}
+		g.insert(c.X, c.Y, len(circles))
+		circles = append(circles, &geom.Circle{Center: c, Radius: r})
+		fails = 0
+	}
+	return circles
+}
+
+// chainCircle is a circle on the front chain, linked to its neighbors either way around it.
+type chainCircle struct {
+	x, y, r    float64
+	next, prev *chainCircle
+}
+
+// FrontChain packs circles tightly outwards from the middle of the region, with the front chain algorithm
+// of Wang et al, leaving out circles that don't fit wholly inside it. The chain search follows packSiblings
+// in d3-hierarchy.
+// Radii are random between MinRadius and MaxRadius, or set by Density if it is not nil.
+func (p *Packer) FrontChain() []*geom.Circle {
+	bounds := p.Region.Bounds()
+	cx, cy := bounds.X+bounds.W/2, bounds.Y+bounds.H/2
+	// packing stops once the chain is far enough out to have covered the whole region.
+	reach := math.Hypot(bounds.W, bounds.H)/2 + p.MaxRadius*2 + p.Padding
+	if p.MinRadius <= 0 || p.MaxRadius < p.MinRadius {
+		return nil
+	}
+	radius := func(x, y float64) float64 {
+		if p.Density != nil {
+			return p.radiusAt(cx+x, cy+y) + p.Padding/2
+		}
+		return random.FloatRange(p.MinRadius, p.MaxRadius) + p.Padding/2
+	}
+
+	var circles []*geom.Circle
+	keep := func(c *chainCircle) {
+		r := c.r - p.Padding/2
+		x, y := cx+c.x, cy+c.y
+		if p.Region.Contains(x, y) && p.Region.EdgeDistance(x, y) >= r {
+			circles = append(circles, geom.NewCircle(x, y, r))
+		}
+	}
+
+	// start with three circles touching around the middle.
+	a := &chainCircle{r: radius(0, 0)}
+	b := &chainCircle{r: radius(0, 0)}
+	a.x = -b.r
+	b.x = a.r
+	c := &chainCircle{r: radius(0, 0)}
+	placeTangent(b, a, c)
+	a.next, c.prev = b, b
+	b.next, a.prev = c, c
+	c.next, b.prev = a, a
+	keep(a)
+	keep(b)
+	keep(c)
+
+	for {
+		// place the next circle against the pair of chain circles nearest the middle.
+		x, y := chainScore(a)
+		if math.Hypot(x, y) > reach {
+			return circles
+		}
+		c = &chainCircle{r: radius(x, y)}
+		for {
+			placeTangent(a, b, c)
+			// look for the nearest circle along the chain either way that overlaps the new one. If there is one,
+			// cut the chain between it and the pair, and try again against the shorter chain.
+			j, k := b.next, a.prev
+			sj, sk := b.r, a.r
+			overlapped := false
+			for j != k.next {
+				if sj <= sk {
+					if overlaps(j, c) {
+						b = j
+						a.next, b.prev = b, a
+						overlapped = true
+						break
+					}
+					sj += j.r
+					j = j.next
+				} else {
+					if overlaps(k, c) {
+						a = k
+						a.next, b.prev = b, a
+						overlapped = true
+						break
+					}
+					sk += k.r
+					k = k.prev
+				}
+			}
+			if !overlapped {
+				break
+			}
+		}
+		c.prev, c.next = a, b
+		a.next, b.prev = c, c
+		keep(c)
+
+		// move on to the pair now nearest the middle.
+		a = c
+		best := chainDistance(a)
+		for n := c.next; n != c; n = n.next {
+			if d := chainDistance(n); d < best {
+				a, best = n, d
+			}
+		}
+		b = a.next
+	}
+}
+
+// placeTangent moves c to touch both a and b, on the outside of the chain going from a to b.
+// It follows place in d3-hierarchy's packSiblings.
+func placeTangent(a, b, c *chainCircle) {
+	dx, dy := a.x-b.x, a.y-b.y
+	d2 := dx*dx + dy*dy
+	if d2 == 0 {
+		c.x, c.y = b.x+c.r, b.y
+		return
+	}
+	a2 := (a.r + c.r) * (a.r + c.r)
+	b2 := (b.r + c.r) * (b.r + c.r)
+	if b2 > a2 {
+		x := (d2 + a2 - b2) / (2 * d2)
+		y := math.Sqrt(math.Max(0, a2/d2-x*x))
+		c.x, c.y = a.x-x*dx-y*dy, a.y-x*dy+y*dx
+	} else {
+		x := (d2 + b2 - a2) / (2 * d2)
+		y := math.Sqrt(math.Max(0, b2/d2-x*x))
+		c.x, c.y = b.x+x*dx-y*dy, b.y+x*dy+y*dx
+	}
+}
+
+func overlaps(a, b *chainCircle) bool {
+	dr := a.r + b.r - 1e-6
+	dx, dy := b.x-a.x, b.y-a.y
+	return dr > 0 && dr*dr > dx*dx+dy*dy
+}
+
+// chainScore returns the point where a chain circle touches the next one.
+func chainScore(c *chainCircle) (float64, float64) {
+	n := c.next
+	ab := c.r + n.r
+	return (c.x*n.r + n.x*c.r) / ab, (c.y*n.r + n.y*c.r) / ab
+}
+
+func chainDistance(c *chainCircle) float64 {
+	x, y := chainScore(c)
+	return x*x + y*y
+}
+
+// grid finds shapes near a point, holding their indices in cells as big as the largest shape.
+type grid struct {
+	bounds     *geom.Rectangle
+	cell       float64
+	cols, rows int
+	cells      [][]int
+}
+
+func newGrid(bounds *geom.Rectangle, cell float64) *grid {
+	cell = math.Max(cell, 1e-9)
+	cols := int(math.Ceil(bounds.W/cell)) + 1
+	rows := int(math.Ceil(bounds.H/cell)) + 1
+	return &grid{bounds, cell, cols, rows, make([][]int, cols*rows)}
+}
+
+func (g *grid) cellOf(x, y float64) (int, int) {
+	col := int((x - g.bounds.X) / g.cell)
+	row := int((y - g.bounds.Y) / g.cell)
+	return col, row
+}
+
+func (g *grid) insert(x, y float64, i int) {
+	col, row := g.cellOf(x, y)
+	if col >= 0 && col < g.cols && row >= 0 && row < g.rows {
+		g.cells[col+row*g.cols] = append(g.cells[col+row*g.cols], i)
+	}
+}
+
+// each calls f with every shape in the cells around a point.
+func (g *grid) each(x, y float64, f func(i int)) {
+	col, row := g.cellOf(x, y)
+	for r := row - 1; r <= row+1; r++ {
+		for c := col - 1; c <= col+1; c++ {
+			if c < 0 || c >= g.cols || r < 0 || r >= g.rows {
+				continue
+			}
+			for _, i := range g.cells[c+r*g.cols] {
+				f(i)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package packing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/random"
+)
+
+func checkCircles(t *testing.T, circles []*geom.Circle, region Region, minRadius, maxRadius, padding float64) {
+	t.Helper()
+	for i, a := range circles {
+		if a.Radius < minRadius-1e-9 || a.Radius > maxRadius+1e-9 {
+			t.Errorf("circle %d has radius %f", i, a.Radius)
+		}
+		if !region.Contains(a.Center.X, a.Center.Y) || region.EdgeDistance(a.Center.X, a.Center.Y) < a.Radius-1e-9 {
+			t.Errorf("circle %d crosses the edge of the region", i)
+		}
+		for _, b := range circles[i+1:] {
+			if a.Center.Distance(b.Center) < a.Radius+b.Radius+padding-1e-6 {
+				t.Fatalf("circles %v and %v overlap", a, b)
+			}
+		}
+	}
+}
+
+func coverage(circles []*geom.Circle) float64 {
+	area := 0.0
+	for _, c := range circles {
+		area += math.Pi * c.Radius * c.Radius
+	}
+	return area
+}
+
+func TestGrow(t *testing.T) {
+	random.Seed(1)
+	region := RectangleRegion(geom.NewRectangle(0, 0, 200, 100))
+	packer := NewPacker(region, 2, 20)
+	packer.Padding = 1
+	circles := packer.Grow()
+	checkCircles(t, circles, region, 2, 20, 1)
+	if c := coverage(circles) / (200 * 100); c < 0.6 {
+		t.Errorf("circles cover %f of the region", c)
+	}
+
+	random.Seed(1)
+	again := packer.Grow()
+	if len(again) != len(circles) || again[5].Radius != circles[5].Radius {
+		t.Errorf("seeding again gave different circles")
+	}
+}
+
+func TestGrowDensity(t *testing.T) {
+	random.Seed(1)
+	region := CircleRegion(geom.NewCircle(100, 100, 100))
+	packer := NewPacker(region, 2, 10)
+	packer.Density = func(x, y float64) float64 {
+		return x / 200
+	}
+	circles := packer.Grow()
+	checkCircles(t, circles, region, 2, 10, 0)
+	for _, c := range circles {
+		if max := 10 - 8*c.Center.X/200; c.Radius > max+1e-9 {
+			t.Fatalf("circle at %v has radius %f, want at most %f", c.Center, c.Radius, max)
+		}
+	}
+}
+
+func TestFrontChain(t *testing.T) {
+	random.Seed(1)
+	// an L shape.
+	polygon := geom.Polygon{{
+		geom.NewPoint(0, 0), geom.NewPoint(200, 0), geom.NewPoint(200, 80),
+		geom.NewPoint(80, 80), geom.NewPoint(80, 200), geom.NewPoint(0, 200),
+	}}
+	region := PolygonRegion(polygon)
+	packer := NewPacker(region, 3, 6)
+	packer.Padding = 0.5
+	circles := packer.FrontChain()
+	checkCircles(t, circles, region, 3, 6, 0.5)
+	if c := coverage(circles) / polygon.Area(); c < 0.6 {
+		t.Errorf("circles cover %f of the region", c)
+	}
+}
+
+func TestGrowShapes(t *testing.T) {
+	random.Seed(1)
+	region := CircleRegion(geom.NewCircle(50, 50, 50))
+	packer := NewPacker(region, 3, 15)
+	packer.Padding = 1
+	square := []*geom.Point{
+		geom.NewPoint(-1, -1), geom.NewPoint(1, -1), geom.NewPoint(1, 1), geom.NewPoint(-1, 1),
+	}
+	shapes := packer.GrowShapes(square, true)
+	if len(shapes) < 20 {
+		t.Fatalf("packed %d shapes", len(shapes))
+	}
+	for i, a := range shapes {
+		for _, q := range a {
+			if !region.Contains(q.X, q.Y) {
+				t.Errorf("shape %d corner %v is outside the region", i, q)
+			}
+		}
+		for _, b := range shapes[i+1:] {
+			if !separated(a, b, 1-1e-9) {
+				t.Fatalf("shapes %v and %v overlap", a, b)
+			}
+		}
+	}
+}
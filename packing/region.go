@@ -0,0 +1,93 @@
+package packing
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+)
+
+// Region is an area to pack shapes into.
+type Region interface {
+	// Bounds returns the smallest rectangle containing the region.
+	Bounds() *geom.Rectangle
+	// Contains returns whether a point is inside the region.
+	Contains(x, y float64) bool
+	// EdgeDistance returns the distance from a point inside the region to its nearest edge.
+	EdgeDistance(x, y float64) float64
+}
+
+type rectangleRegion struct {
+	rect *geom.Rectangle
+}
+
+// RectangleRegion returns a rectangular region.
+func RectangleRegion(rect *geom.Rectangle) Region {
+	return &rectangleRegion{rect}
+}
+
+func (r *rectangleRegion) Bounds() *geom.Rectangle {
+	return r.rect
+}
+
+func (r *rectangleRegion) Contains(x, y float64) bool {
+	return geom.InRect(x, y, r.rect.X, r.rect.Y, r.rect.W, r.rect.H)
+}
+
+func (r *rectangleRegion) EdgeDistance(x, y float64) float64 {
+	return math.Min(
+		math.Min(x-r.rect.X, r.rect.X+r.rect.W-x),
+		math.Min(y-r.rect.Y, r.rect.Y+r.rect.H-y),
+	)
+}
+
+type circleRegion struct {
+	circle *geom.Circle
+}
+
+// CircleRegion returns a circular region.
+func CircleRegion(circle *geom.Circle) Region {
+	return &circleRegion{circle}
+}
+
+func (c *circleRegion) Bounds() *geom.Rectangle {
+	r := c.circle.Radius
+	return geom.NewRectangle(c.circle.Center.X-r, c.circle.Center.Y-r, r*2, r*2)
+}
+
+func (c *circleRegion) Contains(x, y float64) bool {
+	return geom.InCircle(x, y, c.circle.Center.X, c.circle.Center.Y, c.circle.Radius)
+}
+
+func (c *circleRegion) EdgeDistance(x, y float64) float64 {
+	return c.circle.Radius - geom.Distance(x, y, c.circle.Center.X, c.circle.Center.Y)
+}
+
+type polygonRegion struct {
+	polygon geom.Polygon
+	bounds  *geom.Rectangle
+}
+
+// PolygonRegion returns a region inside a polygon, which may have holes.
+func PolygonRegion(polygon geom.Polygon) Region {
+	return &polygonRegion{polygon, polygon.Bounds()}
+}
+
+func (p *polygonRegion) Bounds() *geom.Rectangle {
+	return p.bounds
+}
+
+func (p *polygonRegion) Contains(x, y float64) bool {
+	return geom.PointInPolygon(geom.NewPoint(x, y), p.polygon)
+}
+
+func (p *polygonRegion) EdgeDistance(x, y float64) float64 {
+	point := geom.NewPoint(x, y)
+	dist := math.MaxFloat64
+	for _, ring := range p.polygon {
+		n := len(ring)
+		for i := range ring {
+			dist = math.Min(dist, geom.DistanceToSegment(point, ring[i], ring[(i+1)%n]))
+		}
+	}
+	return dist
+}
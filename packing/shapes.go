@@ -0,0 +1,118 @@
+package packing
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/random"
+)
+
+// GrowShapes packs copies of a convex shape, given as a ring of points around the origin, as Grow packs circles:
+// each copy is placed at a random point and scaled up until it touches the edge of the region or another copy.
+// Radius here is the distance from a copy's center to its farthest corner.
+// If rotate is true, each copy is turned to a random angle. The packed copies are returned as rings of points.
+func (p *Packer) GrowShapes(shape []*geom.Point, rotate bool) [][]*geom.Point {
+	size := 0.0
+	for _, q := range shape {
+		size = math.Max(size, q.Magnitude())
+	}
+	if size == 0 || p.MinRadius <= 0 {
+		return nil
+	}
+	bounds := p.Region.Bounds()
+	g := newGrid(bounds, p.MaxRadius*2+p.Padding)
+	var shapes [][]*geom.Point
+
+	// place returns a copy of the shape at a point, scaled to a radius.
+	place := func(c *geom.Point, angle, r float64) []*geom.Point {
+		scale := r / size
+		cos, sin := math.Cos(angle)*scale, math.Sin(angle)*scale
+		placed := make([]*geom.Point, len(shape))
+		for i, q := range shape {
+			placed[i] = geom.NewPoint(c.X+q.X*cos-q.Y*sin, c.Y+q.X*sin+q.Y*cos)
+		}
+		return placed
+	}
+	// fits returns whether a copy placed with a radius lies inside the region, clear of the others.
+	fits := func(c *geom.Point, placed []*geom.Point, r float64) bool {
+		// a copy wholly within the region's edge distance is inside it. Otherwise, check points along its edges.
+		if p.Region.EdgeDistance(c.X, c.Y) < r {
+			for i := range placed {
+				for t := 0.0; t < 1; t += 0.125 {
+					q := geom.LerpPoint(t, placed[i], placed[(i+1)%len(placed)])
+					if !p.Region.Contains(q.X, q.Y) {
+						return false
+					}
+				}
+			}
+		}
+		clear := true
+		g.each(c.X, c.Y, func(i int) {
+			clear = clear && separated(placed, shapes[i], p.Padding)
+		})
+		return clear
+	}
+
+	for fails := 0; fails < p.Attempts; {
+		c := geom.RandomPoint(bounds.X, bounds.Y, bounds.W, bounds.H)
+		angle := 0.0
+		if rotate {
+			angle = random.FloatRange(0, math.Pi*2)
+		}
+		if !p.Region.Contains(c.X, c.Y) || !fits(c, place(c, angle, p.MinRadius), p.MinRadius) {
+			fails++
+			continue
+		}
+		// find the largest size that fits, between the smallest allowed and the largest.
+		lo, hi := p.MinRadius, p.radiusAt(c.X, c.Y)
+		if hi > lo && fits(c, place(c, angle, hi), hi) {
+			lo = hi
+		}
+		for i := 0; i < 20 && hi-lo > 0.01; i++ {
+			mid := (lo + hi) / 2
+			if fits(c, place(c, angle, mid), mid) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		g.insert(c.X, c.Y, len(shapes))
+		shapes = append(shapes, place(c, angle, lo))
+		fails = 0
+	}
+	return shapes
+}
+
+// separated returns whether two convex rings are at least a gap apart, along the normal of one of their edges.
+func separated(a, b []*geom.Point, gap float64) bool {
+	return separatedBy(a, b, gap) || separatedBy(b, a, gap)
+}
+
+// separatedBy tests the normals of the edges of ring a.
+func separatedBy(a, b []*geom.Point, gap float64) bool {
+	for i := range a {
+		p0, p1 := a[i], a[(i+1)%len(a)]
+		nx, ny := p1.Y-p0.Y, p0.X-p1.X
+		length := math.Hypot(nx, ny)
+		if length == 0 {
+			continue
+		}
+		nx, ny = nx/length, ny/length
+		minA, maxA := project(a, nx, ny)
+		minB, maxB := project(b, nx, ny)
+		if minB-maxA >= gap || minA-maxB >= gap {
+			return true
+		}
+	}
+	return false
+}
+
+func project(ring []*geom.Point, nx, ny float64) (float64, float64) {
+	min, max := math.MaxFloat64, -math.MaxFloat64
+	for _, q := range ring {
+		d := q.X*nx + q.Y*ny
+		min = math.Min(min, d)
+		max = math.Max(max, d)
+	}
+	return min, max
+}
@@ -0,0 +1,167 @@
+package blgo
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/blmath"
+	"github.com/bit101/blgo/color"
+)
+
+// InBounds returns whether or not an x, y pixel location is within the surface.
+func (s *Surface) InBounds(x, y int) bool {
+	return x >= 0 && x < s.GetWidth() && y >= 0 && y < s.GetHeight()
+}
+
+// GetPixelColor returns the color of the pixel at a given x, y location.
+// Alpha is un-premultiplied. Locations outside the surface return a transparent color.
+// Each call copies the whole surface, so use GetPixels to read many pixels.
+func (s *Surface) GetPixelColor(x, y int) color.Color {
+	if !s.InBounds(x, y) {
+		return color.RGBA(0, 0, 0, 0)
+	}
+	return s.GetPixels().GetColor(x, y)
+}
+
+// SetPixelColor sets the color of the pixel at a given x, y location.
+// Locations outside the surface are ignored.
+// Each call copies the whole surface twice, so use GetPixels and SetPixels to change many pixels.
+func (s *Surface) SetPixelColor(x, y int, c color.Color) {
+	if !s.InBounds(x, y) {
+		return
+	}
+	pixels := s.GetPixels()
+	pixels.SetColor(x, y, c)
+	s.SetPixels(pixels)
+}
+
+// GetRow returns the colors of every pixel in a single row of the surface.
+// Returns nil if the row is outside the surface.
+// Each call copies the whole surface, so use GetPixels to read many rows.
+func (s *Surface) GetRow(y int) []color.Color {
+	if y < 0 || y >= s.GetHeight() {
+		return nil
+	}
+	return s.GetPixels().GetRow(y)
+}
+
+// SetRow sets the colors of a single row of the surface.
+// Colors beyond the width of the surface and rows outside the surface are ignored.
+// Each call copies the whole surface twice, so use GetPixels and SetPixels to change many rows.
+func (s *Surface) SetRow(y int, row []color.Color) {
+	if y < 0 || y >= s.GetHeight() {
+		return
+	}
+	pixels := s.GetPixels()
+	pixels.SetRow(y, row)
+	s.SetPixels(pixels)
+}
+
+// Pixels holds a copy of the pixel data of a surface, for reading and changing many pixels at once.
+// Changes are not seen on the surface until the pixels are written back with SetPixels.
+type Pixels struct {
+	Width  int
+	Height int
+	stride int
+	data   []byte
+}
+
+// GetPixels returns a copy of the pixel data of the surface.
+func (s *Surface) GetPixels() *Pixels {
+	s.Flush()
+	return &Pixels{
+		Width:  s.GetWidth(),
+		Height: s.GetHeight(),
+		stride: s.GetStride(),
+		data:   s.GetData(),
+	}
+}
+
+// SetPixels writes pixel data from GetPixels back to the surface.
+func (s *Surface) SetPixels(pixels *Pixels) {
+	s.Flush()
+	s.SetData(pixels.data)
+	s.MarkDirty()
+}
+
+// InBounds returns whether or not an x, y pixel location is within the pixels.
+func (p *Pixels) InBounds(x, y int) bool {
+	return x >= 0 && x < p.Width && y >= 0 && y < p.Height
+}
+
+// GetColor returns the color of the pixel at a given x, y location.
+// Alpha is un-premultiplied. Locations outside the pixels return a transparent color.
+func (p *Pixels) GetColor(x, y int) color.Color {
+	if !p.InBounds(x, y) {
+		return color.RGBA(0, 0, 0, 0)
+	}
+	return decodePixel(p.data, y*p.stride+x*4)
+}
+
+// SetColor sets the color of the pixel at a given x, y location.
+// Locations outside the pixels are ignored.
+func (p *Pixels) SetColor(x, y int, c color.Color) {
+	if !p.InBounds(x, y) {
+		return
+	}
+	encodePixel(p.data, y*p.stride+x*4, c)
+}
+
+// GetRow returns the colors of every pixel in a single row.
+// Returns nil if the row is outside the pixels.
+func (p *Pixels) GetRow(y int) []color.Color {
+	if y < 0 || y >= p.Height {
+		return nil
+	}
+	row := make([]color.Color, p.Width)
+	index := y * p.stride
+	for x := range row {
+		row[x] = decodePixel(p.data, index+x*4)
+	}
+	return row
+}
+
+// SetRow sets the colors of a single row.
+// Colors beyond the width and rows outside the pixels are ignored.
+func (p *Pixels) SetRow(y int, row []color.Color) {
+	if y < 0 || y >= p.Height {
+		return
+	}
+	index := y * p.stride
+	for x := 0; x < p.Width && x < len(row); x++ {
+		encodePixel(p.data, index+x*4, row[x])
+	}
+}
+
+// pixelIndex returns the index of a pixel in the surface data, accounting for row stride.
+func (s *Surface) pixelIndex(x, y int) int {
+	return y*s.GetStride() + x*4
+}
+
+// decodePixel reads a premultiplied b, g, r, a pixel at the given index into a color.
+func decodePixel(data []byte, index int) color.Color {
+	a := data[index+3]
+	if a == 0 {
+		return color.RGBA(0, 0, 0, 0)
+	}
+	alpha := float64(a)
+	return color.RGBA(
+		math.Min(float64(data[index+2])/alpha, 1),
+		math.Min(float64(data[index+1])/alpha, 1),
+		math.Min(float64(data[index])/alpha, 1),
+		alpha/255.0,
+	)
+}
+
+// encodePixel writes a color into the surface data at the given index as premultiplied b, g, r, a.
+func encodePixel(data []byte, index int, c color.Color) {
+	a := blmath.Clamp(c.A, 0, 1)
+	data[index] = toByte(c.B * a)
+	data[index+1] = toByte(c.G * a)
+	data[index+2] = toByte(c.R * a)
+	data[index+3] = toByte(a)
+}
+
+// toByte converts a value from 0.0 to 1.0 to a byte from 0 to 255.
+func toByte(value float64) byte {
+	return byte(math.Round(blmath.Clamp(value, 0, 1) * 255))
+}
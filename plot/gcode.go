@@ -0,0 +1,60 @@
+package plot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bit101/blgo/geom"
+)
+
+// WriteGCode writes a set of paths as G-code.
+func WriteGCode(w io.Writer, lines [][]*geom.Point, config *Config) error {
+	err := config.checkBounds(lines)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "G21 ; millimeters")
+	fmt.Fprintln(bw, "G90 ; absolute positioning")
+	fmt.Fprintln(bw, config.PenUp)
+	for _, line := range lines {
+		if len(line) < 2 {
+			continue
+		}
+		x, y := config.toPaper(line[0])
+		fmt.Fprintf(bw, "G0 X%.3f Y%.3f F%.0f\n", x, y, config.TravelSpeed)
+		fmt.Fprintln(bw, config.PenDown)
+		for i, p := range line[1:] {
+			x, y := config.toPaper(p)
+			if i == 0 {
+				fmt.Fprintf(bw, "G1 X%.3f Y%.3f F%.0f\n", x, y, config.DrawSpeed)
+			} else {
+				fmt.Fprintf(bw, "G1 X%.3f Y%.3f\n", x, y)
+			}
+		}
+		fmt.Fprintln(bw, config.PenUp)
+	}
+	fmt.Fprintf(bw, "G0 X0 Y0 F%.0f\n", config.TravelSpeed)
+	return bw.Flush()
+}
+
+// WriteGCodeFile writes a set of paths to a G-code file.
+func WriteGCodeFile(filename string, lines [][]*geom.Point, config *Config) error {
+	return writeFile(filename, lines, config, WriteGCode)
+}
+
+func writeFile(filename string, lines [][]*geom.Point, config *Config,
+	write func(io.Writer, [][]*geom.Point, *Config) error) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = write(file, lines, config)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
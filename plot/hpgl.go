@@ -0,0 +1,50 @@
+package plot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/bit101/blgo/geom"
+)
+
+// hpglUnits is the number of HPGL plotter units per millimeter.
+const hpglUnits = 40.0
+
+// WriteHPGL writes a set of paths as HPGL, using pen 1.
+func WriteHPGL(w io.Writer, lines [][]*geom.Point, config *Config) error {
+	err := config.checkBounds(lines)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "IN;SP1;")
+	for _, line := range lines {
+		if len(line) < 2 {
+			continue
+		}
+		x, y := hpgl(config, line[0])
+		fmt.Fprintf(bw, "PU%d,%d;PD", x, y)
+		for i, p := range line[1:] {
+			x, y := hpgl(config, p)
+			if i > 0 {
+				fmt.Fprint(bw, ",")
+			}
+			fmt.Fprintf(bw, "%d,%d", x, y)
+		}
+		fmt.Fprint(bw, ";")
+	}
+	fmt.Fprintln(bw, "PU0,0;SP0;")
+	return bw.Flush()
+}
+
+// WriteHPGLFile writes a set of paths to an HPGL file.
+func WriteHPGLFile(filename string, lines [][]*geom.Point, config *Config) error {
+	return writeFile(filename, lines, config, WriteHPGL)
+}
+
+func hpgl(config *Config, p *geom.Point) (int, int) {
+	x, y := config.toPaper(p)
+	return int(math.Round(x * hpglUnits)), int(math.Round(y * hpglUnits))
+}
@@ -0,0 +1,389 @@
+package plot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/bit101/blgo/geom"
+)
+
+// angleTolerance is the maximum difference in angle, in radians, for two segments to be considered collinear.
+const angleTolerance = 1e-4
+
+// maxPasses limits the number of 2-opt passes made over a route.
+const maxPasses = 50
+
+// Report compares paths before and after optimization. Distances are in drawing units,
+// so multiply them by the config's Scale to compare them with DryRun stats.
+type Report struct {
+	PathsBefore  int
+	PathsAfter   int
+	DrawBefore   float64
+	DrawAfter    float64
+	TravelBefore float64
+	TravelAfter  float64
+}
+
+// String returns a readable summary of the report.
+func (r Report) String() string {
+	return fmt.Sprintf("paths: %d -> %d, draw: %.1f -> %.1f, travel: %.1f -> %.1f",
+		r.PathsBefore, r.PathsAfter, r.DrawBefore, r.DrawAfter, r.TravelBefore, r.TravelAfter)
+}
+
+// Optimize removes duplicate and overlapping segments from a set of paths,
+// joins paths that meet end to end, and reorders them to reduce pen travel.
+// Points closer than tolerance are considered the same.
+// Travel is measured from and back to the paper origin, as set by the config.
+func Optimize(lines [][]*geom.Point, tolerance float64, config *Config) ([][]*geom.Point, Report) {
+	home := config.home()
+	report := Report{
+		PathsBefore:  len(lines),
+		DrawBefore:   drawDistance(lines),
+		TravelBefore: travelDistance(lines, home),
+	}
+	lines = Sort(MergeSegments(lines, tolerance), config)
+	report.PathsAfter = len(lines)
+	report.DrawAfter = drawDistance(lines)
+	report.TravelAfter = travelDistance(lines, home)
+	return lines, report
+}
+
+////////////////////////////////////////
+// Merging
+////////////////////////////////////////
+
+type segment struct {
+	a, b *geom.Point
+}
+
+// MergeSegments breaks paths into segments, merges collinear segments that overlap,
+// including exact duplicates, and joins the results into paths wherever they meet end to end.
+func MergeSegments(lines [][]*geom.Point, tolerance float64) [][]*geom.Point {
+	var segments []segment
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			if line[i-1].Distance(line[i]) > tolerance {
+				segments = append(segments, segment{line[i-1], line[i]})
+			}
+		}
+	}
+	return chainSegments(mergeCollinear(segments, tolerance), tolerance)
+}
+
+// lineSegment is a segment described by the line it lies on.
+type lineSegment struct {
+	segment
+	angle, offset float64
+}
+
+func mergeCollinear(segments []segment, tolerance float64) []segment {
+	items := make([]lineSegment, len(segments))
+	for i, s := range segments {
+		angle := math.Atan2(s.b.Y-s.a.Y, s.b.X-s.a.X)
+		if angle < 0 {
+			angle += math.Pi
+		}
+		if angle >= math.Pi-angleTolerance {
+			angle -= math.Pi
+		}
+		items[i] = lineSegment{s, angle, s.a.Y*math.Cos(angle) - s.a.X*math.Sin(angle)}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].angle < items[j].angle
+	})
+
+	var result []segment
+	// group by angle, then within each angle group by offset, then merge overlapping intervals.
+	for _, byAngle := range groupBy(items, func(s lineSegment) float64 { return s.angle }, angleTolerance) {
+		sort.Slice(byAngle, func(i, j int) bool {
+			return byAngle[i].offset < byAngle[j].offset
+		})
+		for _, group := range groupBy(byAngle, func(s lineSegment) float64 { return s.offset }, tolerance) {
+			result = append(result, mergeGroup(group, tolerance)...)
+		}
+	}
+	return result
+}
+
+// groupBy splits sorted items into runs where consecutive values differ by no more than tolerance.
+func groupBy(items []lineSegment, value func(lineSegment) float64, tolerance float64) [][]lineSegment {
+	var groups [][]lineSegment
+	start := 0
+	for i := 1; i <= len(items); i++ {
+		if i == len(items) || value(items[i])-value(items[i-1]) > tolerance {
+			groups = append(groups, items[start:i])
+			start = i
+		}
+	}
+	return groups
+}
+
+// mergeGroup merges the overlapping segments in a group of collinear segments.
+func mergeGroup(group []lineSegment, tolerance float64) []segment {
+	if len(group) == 1 {
+		return []segment{group[0].segment}
+	}
+	angle := group[0].angle
+	dx, dy := math.Cos(angle), math.Sin(angle)
+	type interval struct {
+		t0, t1 float64
+		a, b   *geom.Point
+	}
+	intervals := make([]interval, len(group))
+	for i, s := range group {
+		a, b := s.a, s.b
+		t0, t1 := a.X*dx+a.Y*dy, b.X*dx+b.Y*dy
+		if t0 > t1 {
+			t0, t1 = t1, t0
+			a, b = b, a
+		}
+		intervals[i] = interval{t0, t1, a, b}
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].t0 < intervals[j].t0
+	})
+	var result []segment
+	current := intervals[0]
+	for _, next := range intervals[1:] {
+		if next.t0 <= current.t1+tolerance {
+			if next.t1 > current.t1 {
+				current.t1 = next.t1
+				current.b = next.b
+			}
+			continue
+		}
+		result = append(result, segment{current.a, current.b})
+		current = next
+	}
+	return append(result, segment{current.a, current.b})
+}
+
+// endIndex finds segment end points near a location.
+type endIndex struct {
+	cells     map[[2]int][]int
+	segments  []segment
+	tolerance float64
+}
+
+func newEndIndex(segments []segment, tolerance float64) *endIndex {
+	if tolerance <= 0 {
+		tolerance = 1e-9
+	}
+	index := &endIndex{
+		cells:     map[[2]int][]int{},
+		segments:  segments,
+		tolerance: tolerance,
+	}
+	for i, s := range segments {
+		index.add(s.a, i*2)
+		index.add(s.b, i*2+1)
+	}
+	return index
+}
+
+func (e *endIndex) cell(p *geom.Point) [2]int {
+	return [2]int{int(math.Floor(p.X / e.tolerance)), int(math.Floor(p.Y / e.tolerance))}
+}
+
+func (e *endIndex) add(p *geom.Point, end int) {
+	c := e.cell(p)
+	e.cells[c] = append(e.cells[c], end)
+}
+
+func (e *endIndex) point(end int) *geom.Point {
+	if end%2 == 0 {
+		return e.segments[end/2].a
+	}
+	return e.segments[end/2].b
+}
+
+// find returns an end of an unused segment within tolerance of a point, or -1.
+func (e *endIndex) find(p *geom.Point, used []bool) int {
+	c := e.cell(p)
+	for x := c[0] - 1; x <= c[0]+1; x++ {
+		for y := c[1] - 1; y <= c[1]+1; y++ {
+			for _, end := range e.cells[[2]int{x, y}] {
+				if !used[end/2] && e.point(end).Distance(p) <= e.tolerance {
+					return end
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// chainSegments joins segments that meet end to end into paths.
+func chainSegments(segments []segment, tolerance float64) [][]*geom.Point {
+	index := newEndIndex(segments, tolerance)
+	used := make([]bool, len(segments))
+	var lines [][]*geom.Point
+	// the end opposite to a given end of a segment.
+	other := func(end int) *geom.Point {
+		return index.point(end ^ 1)
+	}
+	for i, s := range segments {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		forward := []*geom.Point{s.a, s.b}
+		for end := index.find(s.b, used); end >= 0; end = index.find(forward[len(forward)-1], used) {
+			used[end/2] = true
+			forward = append(forward, other(end))
+		}
+		var backward []*geom.Point
+		for end := index.find(s.a, used); end >= 0; end = index.find(backward[len(backward)-1], used) {
+			used[end/2] = true
+			backward = append(backward, other(end))
+		}
+		line := make([]*geom.Point, 0, len(backward)+len(forward))
+		for j := len(backward) - 1; j >= 0; j-- {
+			line = append(line, backward[j])
+		}
+		lines = append(lines, append(line, forward...))
+	}
+	return lines
+}
+
+////////////////////////////////////////
+// Sorting
+////////////////////////////////////////
+
+// route is a path that may be drawn in reverse.
+type route struct {
+	points   []*geom.Point
+	reversed bool
+}
+
+func (r route) start() *geom.Point {
+	if r.reversed {
+		return r.points[len(r.points)-1]
+	}
+	return r.points[0]
+}
+
+func (r route) end() *geom.Point {
+	if r.reversed {
+		return r.points[0]
+	}
+	return r.points[len(r.points)-1]
+}
+
+// Sort reorders paths, reversing them where useful, to reduce the distance traveled
+// with the pen up between them. Plotting starts and ends at the paper origin, as set by the config,
+// which is the bottom left corner of the drawing when FlipY is on.
+func Sort(lines [][]*geom.Point, config *Config) [][]*geom.Point {
+	home := config.home()
+	var routes []route
+	for _, line := range lines {
+		if len(line) > 0 {
+			routes = append(routes, route{points: line})
+		}
+	}
+	routes = twoOpt(nearestNeighbor(routes, home), home)
+	result := make([][]*geom.Point, len(routes))
+	for i, r := range routes {
+		if !r.reversed {
+			result[i] = r.points
+			continue
+		}
+		points := make([]*geom.Point, len(r.points))
+		for j, p := range r.points {
+			points[len(points)-1-j] = p
+		}
+		result[i] = points
+	}
+	return result
+}
+
+// nearestNeighbor orders routes by repeatedly choosing the closest end of any remaining route, starting from home.
+func nearestNeighbor(routes []route, home *geom.Point) []route {
+	result := make([]route, 0, len(routes))
+	current := home
+	for len(routes) > 0 {
+		best, bestDist := 0, math.MaxFloat64
+		reversed := false
+		for i, r := range routes {
+			if d := current.Distance(r.points[0]); d < bestDist {
+				best, bestDist, reversed = i, d, false
+			}
+			if d := current.Distance(r.points[len(r.points)-1]); d < bestDist {
+				best, bestDist, reversed = i, d, true
+			}
+		}
+		r := routes[best]
+		r.reversed = reversed
+		result = append(result, r)
+		current = r.end()
+		routes[best] = routes[len(routes)-1]
+		routes = routes[:len(routes)-1]
+	}
+	return result
+}
+
+// twoOpt improves an order of routes by reversing runs of routes wherever that shortens travel,
+// for a plot starting and ending at home.
+func twoOpt(routes []route, home *geom.Point) []route {
+	n := len(routes)
+	for pass := 0; pass < maxPasses; pass++ {
+		improved := false
+		for i := 0; i < n-1; i++ {
+			prev := home
+			if i > 0 {
+				prev = routes[i-1].end()
+			}
+			for j := i + 1; j < n; j++ {
+				next := home
+				if j < n-1 {
+					next = routes[j+1].start()
+				}
+				before := prev.Distance(routes[i].start()) + routes[j].end().Distance(next)
+				after := prev.Distance(routes[j].end()) + routes[i].start().Distance(next)
+				if after < before-1e-9 {
+					reverseRoutes(routes[i : j+1])
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return routes
+}
+
+// reverseRoutes reverses the order of a run of routes, and the direction of each one.
+func reverseRoutes(routes []route) {
+	for i, j := 0, len(routes)-1; i < j; i, j = i+1, j-1 {
+		routes[i], routes[j] = routes[j], routes[i]
+	}
+	for i := range routes {
+		routes[i].reversed = !routes[i].reversed
+	}
+}
+
+func drawDistance(lines [][]*geom.Point) float64 {
+	dist := 0.0
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			dist += line[i-1].Distance(line[i])
+		}
+	}
+	return dist
+}
+
+// travelDistance is the pen up distance from home, between each path and back to home.
+// Like the writers, it skips paths too short to draw.
+func travelDistance(lines [][]*geom.Point, home *geom.Point) float64 {
+	current := home
+	dist := 0.0
+	for _, line := range lines {
+		if len(line) < 2 {
+			continue
+		}
+		dist += current.Distance(line[0])
+		current = line[len(line)-1]
+	}
+	return dist + current.Distance(home)
+}
@@ -0,0 +1,121 @@
+package plot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bit101/blgo/geom"
+)
+
+func line(coords ...float64) []*geom.Point {
+	var points []*geom.Point
+	for i := 0; i < len(coords); i += 2 {
+		points = append(points, geom.NewPoint(coords[i], coords[i+1]))
+	}
+	return points
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	lines := [][]*geom.Point{
+		line(0, 0, 10, 0),
+		line(10, 0, 0, 0),
+		line(0, 0, 10, 0),
+	}
+	result := MergeSegments(lines, 0.01)
+	if len(result) != 1 || drawDistance(result) != 10 {
+		t.Errorf("duplicates not merged: %v", result)
+	}
+}
+
+func TestMergeOverlapping(t *testing.T) {
+	lines := [][]*geom.Point{
+		line(0, 5, 10, 5),
+		line(5, 5, 20, 5),
+	}
+	result := MergeSegments(lines, 0.01)
+	if len(result) != 1 || math.Abs(drawDistance(result)-20) > 1e-9 {
+		t.Errorf("overlapping segments not merged: %v", result)
+	}
+}
+
+func TestMergeKeepsParallel(t *testing.T) {
+	lines := [][]*geom.Point{
+		line(0, 0, 10, 0),
+		line(0, 1, 10, 1),
+	}
+	result := MergeSegments(lines, 0.01)
+	if len(result) != 2 {
+		t.Errorf("parallel segments merged: %v", result)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	lines := [][]*geom.Point{
+		line(10, 0, 10, 10),
+		line(0, 0, 10, 0),
+		line(0, 10, 10, 10),
+	}
+	result := MergeSegments(lines, 0.01)
+	if len(result) != 1 || len(result[0]) != 4 {
+		t.Errorf("segments not joined: %v", result)
+	}
+}
+
+func TestSort(t *testing.T) {
+	var lines [][]*geom.Point
+	// alternate between far apart lines, drawn in awkward directions.
+	for i := 0; i < 10; i++ {
+		x := float64(i%2)*100 + float64(i)
+		lines = append(lines, line(x, 10, x, 0))
+	}
+	config := DefaultConfig()
+	sorted := Sort(lines, config)
+	if len(sorted) != len(lines) {
+		t.Fatalf("got %d lines, want %d", len(sorted), len(lines))
+	}
+	home := config.home()
+	if travelDistance(sorted, home) >= travelDistance(lines, home) {
+		t.Errorf("travel not reduced: %f >= %f", travelDistance(sorted, home), travelDistance(lines, home))
+	}
+
+	// with y flipped, the plotter starts at the bottom left of the drawing.
+	lines = [][]*geom.Point{line(0, 0, 10, 0), line(0, 200, 10, 200)}
+	if sorted := Sort(lines, config); sorted[0][0].Y != 200 {
+		t.Errorf("sorted paths start at %v, want the bottom left", sorted[0][0])
+	}
+	config.FlipY = false
+	if sorted := Sort(lines, config); sorted[0][0].Y != 0 {
+		t.Errorf("unflipped sorted paths start at %v, want the top left", sorted[0][0])
+	}
+}
+
+func TestOptimizeReport(t *testing.T) {
+	lines := [][]*geom.Point{
+		line(50, 0, 60, 0),
+		line(0, 0, 10, 0),
+		line(60, 0, 50, 0),
+		line(20, 0, 30, 0),
+	}
+	config := DefaultConfig()
+	result, report := Optimize(lines, 0.01, config)
+	if report.PathsBefore != 4 || report.PathsAfter != len(result) || report.PathsAfter != 3 {
+		t.Errorf("bad path counts: %v", report)
+	}
+	if report.DrawAfter != 30 || report.DrawBefore != 40 {
+		t.Errorf("bad draw distances: %v", report)
+	}
+	if report.TravelAfter >= report.TravelBefore {
+		t.Errorf("travel not reduced: %v", report)
+	}
+
+	// report travel matches a dry run of the same paths, once scaled to millimeters.
+	config.Scale = 2
+	config.OriginX, config.OriginY = 15, 25
+	lines = append(lines, line(40, 70))
+	result, report = Optimize(lines, 0.01, config)
+	before, after := DryRun(lines, config), DryRun(result, config)
+	if math.Abs(report.TravelBefore*config.Scale-before.TravelDistance) > 1e-9 ||
+		math.Abs(report.TravelAfter*config.Scale-after.TravelDistance) > 1e-9 {
+		t.Errorf("report travel %v, want %f and %f after scaling", report, before.TravelDistance, after.TravelDistance)
+	}
+}
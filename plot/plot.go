@@ -0,0 +1,149 @@
+// Package plot exports drawing paths for pen plotters as G-code or HPGL.
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/vector"
+)
+
+// ErrOutOfBounds is returned when a path goes beyond the edges of the paper.
+var ErrOutOfBounds = errors.New("plot: path is outside of paper bounds")
+
+// Config holds the settings for plotter output. Distances are in millimeters.
+type Config struct {
+	// PaperWidth and PaperHeight are the size of the drawable area.
+	PaperWidth  float64
+	PaperHeight float64
+	// Scale is the number of millimeters per drawing unit.
+	Scale float64
+	// OriginX and OriginY offset the drawing on the paper.
+	OriginX float64
+	OriginY float64
+	// FlipY puts the origin at the bottom left, with y increasing upwards, as G-code and HPGL machines expect.
+	// It is on by default, so drawings come out the way they look on screen.
+	// Turn it off for plotters with y increasing downwards.
+	FlipY bool
+	// Tolerance is the maximum distance, in drawing units, curves deviate when Polylines flattens them to lines.
+	Tolerance float64
+	// PenUp and PenDown are the G-code commands that raise and lower the pen.
+	PenUp   string
+	PenDown string
+	// DrawSpeed and TravelSpeed are feed rates in millimeters per minute.
+	DrawSpeed   float64
+	TravelSpeed float64
+	// PenDelay is the time taken to raise or lower the pen, used for time estimates.
+	PenDelay time.Duration
+}
+
+// DefaultConfig returns a config for A4 paper at one millimeter per drawing unit,
+// with y flipped to increase upwards and servo commands to move the pen.
+func DefaultConfig() *Config {
+	return &Config{
+		PaperWidth:  297,
+		PaperHeight: 210,
+		Scale:       1,
+		FlipY:       true,
+		Tolerance:   0.1,
+		PenUp:       "M3 S30",
+		PenDown:     "M3 S0",
+		DrawSpeed:   1500,
+		TravelSpeed: 3000,
+		PenDelay:    150 * time.Millisecond,
+	}
+}
+
+// Polylines flattens shapes into lists of points, ready for plotting, with curves flattened to the config's tolerance.
+// Filled shapes are plotted as their outlines.
+func Polylines(shapes []*vector.Shape, config *Config) [][]*geom.Point {
+	var lines [][]*geom.Point
+	for _, shape := range shapes {
+		lines = append(lines, shape.Polylines(config.Tolerance)...)
+	}
+	return lines
+}
+
+// toPaper converts drawing units to millimeters on the paper.
+func (c *Config) toPaper(p *geom.Point) (float64, float64) {
+	x := p.X*c.Scale + c.OriginX
+	y := p.Y*c.Scale + c.OriginY
+	if c.FlipY {
+		y = c.PaperHeight - y
+	}
+	return x, y
+}
+
+// home returns the paper origin, where the plotter starts and ends, in drawing units.
+func (c *Config) home() *geom.Point {
+	y := -c.OriginY
+	if c.FlipY {
+		y = c.PaperHeight - c.OriginY
+	}
+	return geom.NewPoint(-c.OriginX/c.Scale, y/c.Scale)
+}
+
+// checkBounds returns ErrOutOfBounds if any point is off the paper.
+func (c *Config) checkBounds(lines [][]*geom.Point) error {
+	for _, line := range lines {
+		for _, p := range line {
+			x, y := c.toPaper(p)
+			if x < 0 || x > c.PaperWidth || y < 0 || y > c.PaperHeight {
+				return fmt.Errorf("%w: %.2f, %.2f", ErrOutOfBounds, x, y)
+			}
+		}
+	}
+	return nil
+}
+
+// Stats reports the movement needed to plot a set of paths.
+type Stats struct {
+	// DrawDistance is the distance moved with the pen down, in millimeters.
+	DrawDistance float64
+	// TravelDistance is the distance moved with the pen up, in millimeters,
+	// including moving from and back to the origin.
+	TravelDistance float64
+	// PenLifts is the number of times the pen is raised.
+	PenLifts int
+	// Time is the estimated plotting time.
+	Time time.Duration
+}
+
+// DryRun calculates the stats for plotting a set of paths without writing any output.
+func DryRun(lines [][]*geom.Point, config *Config) Stats {
+	var stats Stats
+	x, y := 0.0, 0.0
+	for _, line := range lines {
+		if len(line) < 2 {
+			continue
+		}
+		x0, y0 := config.toPaper(line[0])
+		stats.TravelDistance += math.Hypot(x0-x, y0-y)
+		x, y = x0, y0
+		for _, p := range line[1:] {
+			x1, y1 := config.toPaper(p)
+			stats.DrawDistance += math.Hypot(x1-x, y1-y)
+			x, y = x1, y1
+		}
+		stats.PenLifts++
+	}
+	stats.TravelDistance += math.Hypot(x, y)
+	minutes := 0.0
+	if config.DrawSpeed > 0 {
+		minutes += stats.DrawDistance / config.DrawSpeed
+	}
+	if config.TravelSpeed > 0 {
+		minutes += stats.TravelDistance / config.TravelSpeed
+	}
+	stats.Time = time.Duration(minutes*float64(time.Minute)) + config.PenDelay*time.Duration(stats.PenLifts*2)
+	return stats
+}
+
+// String returns a readable summary of the stats.
+func (s Stats) String() string {
+	return fmt.Sprintf("draw: %.1fmm, travel: %.1fmm, pen lifts: %d, time: %s",
+		s.DrawDistance, s.TravelDistance, s.PenLifts, s.Time.Round(time.Second))
+}
@@ -0,0 +1,127 @@
+package plot
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/vector"
+)
+
+func square() [][]*geom.Point {
+	return [][]*geom.Point{{
+		geom.NewPoint(10, 10),
+		geom.NewPoint(20, 10),
+		geom.NewPoint(20, 20),
+		geom.NewPoint(10, 20),
+		geom.NewPoint(10, 10),
+	}}
+}
+
+func TestDryRun(t *testing.T) {
+	config := DefaultConfig()
+	config.DrawSpeed = 60
+	config.TravelSpeed = 60
+	config.PenDelay = 0
+	stats := DryRun(square(), config)
+	if stats.DrawDistance != 40 {
+		t.Errorf("draw distance %f, want 40", stats.DrawDistance)
+	}
+	if stats.PenLifts != 1 {
+		t.Errorf("pen lifts %d, want 1", stats.PenLifts)
+	}
+	// to the start and back again, with y flipped so the square's top left is 200mm up the paper.
+	travel := 2 * geom.Distance(0, 0, 10, 200)
+	if stats.TravelDistance != travel {
+		t.Errorf("travel distance %f, want %f", stats.TravelDistance, travel)
+	}
+	want := time.Duration((40 + travel) * float64(time.Second))
+	if stats.Time.Round(time.Millisecond) != want.Round(time.Millisecond) {
+		t.Errorf("time %s, want %s", stats.Time, want)
+	}
+}
+
+func TestWriteGCode(t *testing.T) {
+	var buf bytes.Buffer
+	config := DefaultConfig()
+	err := WriteGCode(&buf, square(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcode := buf.String()
+	for _, want := range []string{
+		"G0 X10.000 Y200.000 F3000\n" + config.PenDown,
+		"G1 X20.000 Y200.000 F1500",
+		"G1 X20.000 Y190.000\n",
+	} {
+		if !strings.Contains(gcode, want) {
+			t.Errorf("gcode missing %q:\n%s", want, gcode)
+		}
+	}
+	if strings.Count(gcode, config.PenDown) != 1 {
+		t.Errorf("expected one pen down command")
+	}
+}
+
+func TestWriteHPGL(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteHPGL(&buf, square(), DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "IN;SP1;PU400,8000;PD800,8000,800,7600,400,7600,400,8000;PU0,0;SP0;\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	config := DefaultConfig()
+	config.FlipY = false
+	err = WriteHPGL(&buf, square(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "IN;SP1;PU400,400;PD800,400,800,800,400,800,400,400;PU0,0;SP0;\n"
+	if buf.String() != want {
+		t.Errorf("unflipped got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOutOfBounds(t *testing.T) {
+	config := DefaultConfig()
+	config.Scale = 100
+	err := WriteGCode(&bytes.Buffer{}, square(), config)
+	if !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("expected ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestPolylines(t *testing.T) {
+	r := vector.NewRecording(100, 100)
+	r.Rectangle(0, 0, 10, 10)
+	r.Stroke()
+	r.MoveTo(0, 0)
+	r.LineTo(5, 5)
+	r.MoveTo(10, 0)
+	r.LineTo(15, 5)
+	r.Stroke()
+	lines := Polylines(r.Shapes, DefaultConfig())
+	if len(lines) != 3 {
+		t.Errorf("got %d polylines, want 3", len(lines))
+	}
+
+	r = vector.NewRecording(100, 100)
+	r.Arc(50, 50, 40, 0, math.Pi*2)
+	r.Stroke()
+	config := DefaultConfig()
+	fine := len(Polylines(r.Shapes, config)[0])
+	config.Tolerance = 1
+	coarse := len(Polylines(r.Shapes, config)[0])
+	if coarse >= fine {
+		t.Errorf("tolerance of 1 gave %d points, and 0.1 gave %d", coarse, fine)
+	}
+}
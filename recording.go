@@ -0,0 +1,256 @@
+package blgo
+
+import (
+	"github.com/bit101/blgo/color"
+	"github.com/bit101/blgo/vector"
+	cairo "github.com/bit101/go-cairo"
+)
+
+// StartRecording begins capturing all path drawing on the surface into a vector recording.
+// Drawing still happens on the surface as usual.
+// The recording starts with the surface's current transform and line width,
+// but it can't read back the source color, so set that after starting.
+func (s *Surface) StartRecording() *vector.Recording {
+	s.recording = vector.NewRecording(s.Width, s.Height)
+	m := s.Surface.GetMatrix()
+	s.recording.SetMatrix(m.Xx, m.Yx, m.Xy, m.Yy, m.X0, m.Y0)
+	s.recording.SetLineWidth(s.Surface.GetLineWidth())
+	return s.recording
+}
+
+// StopRecording stops capturing drawing and returns the recording.
+func (s *Surface) StopRecording() *vector.Recording {
+	recording := s.recording
+	s.recording = nil
+	return recording
+}
+
+// Recording returns the current recording, or nil if the surface is not recording.
+func (s *Surface) Recording() *vector.Recording {
+	return s.recording
+}
+
+// Replay draws a recording onto the surface.
+func (s *Surface) Replay(recording *vector.Recording) {
+	for _, shape := range recording.Shapes {
+		s.Save()
+		s.IdentityMatrix()
+		s.SetSourceColor(shape.Color)
+		s.SetLineWidth(shape.LineWidth)
+		for _, seg := range shape.Segments {
+			p := seg.Points
+			switch seg.Op {
+			case vector.OpMoveTo:
+				s.MoveTo(p[0].X, p[0].Y)
+			case vector.OpLineTo:
+				s.LineTo(p[0].X, p[0].Y)
+			case vector.OpCurveTo:
+				s.CurveTo(p[0].X, p[0].Y, p[1].X, p[1].Y, p[2].X, p[2].Y)
+			case vector.OpClose:
+				s.ClosePath()
+			}
+		}
+		if shape.Filled {
+			s.Fill()
+		} else {
+			s.Stroke()
+		}
+		s.Restore()
+	}
+}
+
+// The following methods wrap the cairo methods of the same name,
+// passing each call on to the recording, if there is one.
+
+// Save saves the drawing state.
+func (s *Surface) Save() {
+	if s.recording != nil {
+		s.recording.Save()
+	}
+	s.Surface.Save()
+}
+
+// Restore restores the last saved drawing state.
+func (s *Surface) Restore() {
+	if s.recording != nil {
+		s.recording.Restore()
+	}
+	s.Surface.Restore()
+}
+
+// Translate translates the current transform.
+func (s *Surface) Translate(x, y float64) {
+	if s.recording != nil {
+		s.recording.Translate(x, y)
+	}
+	s.Surface.Translate(x, y)
+}
+
+// Rotate rotates the current transform.
+func (s *Surface) Rotate(angle float64) {
+	if s.recording != nil {
+		s.recording.Rotate(angle)
+	}
+	s.Surface.Rotate(angle)
+}
+
+// Scale scales the current transform.
+func (s *Surface) Scale(x, y float64) {
+	if s.recording != nil {
+		s.recording.Scale(x, y)
+	}
+	s.Surface.Scale(x, y)
+}
+
+// IdentityMatrix resets the current transform.
+func (s *Surface) IdentityMatrix() {
+	if s.recording != nil {
+		s.recording.IdentityMatrix()
+	}
+	s.Surface.IdentityMatrix()
+}
+
+// SetMatrix sets the current transform.
+func (s *Surface) SetMatrix(m cairo.Matrix) {
+	if s.recording != nil {
+		s.recording.SetMatrix(m.Xx, m.Yx, m.Xy, m.Yy, m.X0, m.Y0)
+	}
+	s.Surface.SetMatrix(m)
+}
+
+// SetSourceRGB sets the source to an rgb color.
+func (s *Surface) SetSourceRGB(r, g, b float64) {
+	if s.recording != nil {
+		s.recording.SetColor(color.RGB(r, g, b))
+	}
+	s.Surface.SetSourceRGB(r, g, b)
+}
+
+// SetSourceRGBA sets the source to an rgba color.
+func (s *Surface) SetSourceRGBA(r, g, b, a float64) {
+	if s.recording != nil {
+		s.recording.SetColor(color.RGBA(r, g, b, a))
+	}
+	s.Surface.SetSourceRGBA(r, g, b, a)
+}
+
+// SetLineWidth sets the line width.
+func (s *Surface) SetLineWidth(width float64) {
+	if s.recording != nil {
+		s.recording.SetLineWidth(width)
+	}
+	s.Surface.SetLineWidth(width)
+}
+
+// MoveTo begins a new sub path.
+func (s *Surface) MoveTo(x, y float64) {
+	if s.recording != nil {
+		s.recording.MoveTo(x, y)
+	}
+	s.Surface.MoveTo(x, y)
+}
+
+// LineTo adds a line to the path.
+func (s *Surface) LineTo(x, y float64) {
+	if s.recording != nil {
+		s.recording.LineTo(x, y)
+	}
+	s.Surface.LineTo(x, y)
+}
+
+// CurveTo adds a cubic bezier curve to the path.
+func (s *Surface) CurveTo(x0, y0, x1, y1, x2, y2 float64) {
+	if s.recording != nil {
+		s.recording.CurveTo(x0, y0, x1, y1, x2, y2)
+	}
+	s.Surface.CurveTo(x0, y0, x1, y1, x2, y2)
+}
+
+// Arc adds a clockwise circular arc to the path.
+func (s *Surface) Arc(x, y, radius, angle0, angle1 float64) {
+	if s.recording != nil {
+		s.recording.Arc(x, y, radius, angle0, angle1)
+	}
+	s.Surface.Arc(x, y, radius, angle0, angle1)
+}
+
+// ArcNegative adds a counter clockwise circular arc to the path.
+func (s *Surface) ArcNegative(x, y, radius, angle0, angle1 float64) {
+	if s.recording != nil {
+		s.recording.ArcNegative(x, y, radius, angle0, angle1)
+	}
+	s.Surface.ArcNegative(x, y, radius, angle0, angle1)
+}
+
+// Rectangle adds a closed rectangle to the path.
+func (s *Surface) Rectangle(x, y, w, h float64) {
+	if s.recording != nil {
+		s.recording.Rectangle(x, y, w, h)
+	}
+	s.Surface.Rectangle(x, y, w, h)
+}
+
+// ClosePath closes the current sub path.
+func (s *Surface) ClosePath() {
+	if s.recording != nil {
+		s.recording.ClosePath()
+	}
+	s.Surface.ClosePath()
+}
+
+// NewPath clears the current path.
+func (s *Surface) NewPath() {
+	if s.recording != nil {
+		s.recording.NewPath()
+	}
+	s.Surface.NewPath()
+}
+
+// NewSubPath ends the current sub path without closing it.
+func (s *Surface) NewSubPath() {
+	if s.recording != nil {
+		s.recording.NewSubPath()
+	}
+	s.Surface.NewSubPath()
+}
+
+// Stroke strokes and clears the current path.
+func (s *Surface) Stroke() {
+	if s.recording != nil {
+		s.recording.Stroke()
+	}
+	s.Surface.Stroke()
+}
+
+// StrokePreserve strokes the current path without clearing it.
+func (s *Surface) StrokePreserve() {
+	if s.recording != nil {
+		s.recording.StrokePreserve()
+	}
+	s.Surface.StrokePreserve()
+}
+
+// Fill fills and clears the current path.
+func (s *Surface) Fill() {
+	if s.recording != nil {
+		s.recording.Fill()
+	}
+	s.Surface.Fill()
+}
+
+// FillPreserve fills the current path without clearing it.
+func (s *Surface) FillPreserve() {
+	if s.recording != nil {
+		s.recording.FillPreserve()
+	}
+	s.Surface.FillPreserve()
+}
+
+// Clip sets the clip region to the current path and clears the path.
+// Clipping is not captured in recordings.
+func (s *Surface) Clip() {
+	if s.recording != nil {
+		s.recording.NewPath()
+	}
+	s.Surface.Clip()
+}
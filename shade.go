@@ -0,0 +1,93 @@
+package blgo
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/bit101/blgo/color"
+)
+
+// ShadeFunc defines a callback function that returns the color for an x, y location on a surface.
+// It will be called concurrently, so it must be safe to call from multiple goroutines.
+type ShadeFunc func(x, y float64) color.Color
+
+// ShadeOptions holds the settings for Shade.
+type ShadeOptions struct {
+	// Workers is the number of goroutines to render with. Defaults to the number of cpus.
+	Workers int
+	// Samples is the number of samples taken across each axis of a pixel for anti-aliasing.
+	// Each pixel is the average of Samples * Samples colors. Defaults to 1.
+	Samples int
+}
+
+// Shade sets the color of every pixel on the surface by calling a shader function.
+// Rows are split across multiple goroutines and written directly to the pixel data.
+// Pass nil options to use the defaults.
+func (s *Surface) Shade(shader ShadeFunc, options *ShadeOptions) {
+	workers := runtime.NumCPU()
+	samples := 1
+	if options != nil {
+		if options.Workers > 0 {
+			workers = options.Workers
+		}
+		if options.Samples > 0 {
+			samples = options.Samples
+		}
+	}
+	w, h := s.GetWidth(), s.GetHeight()
+	if w == 0 || h == 0 {
+		return
+	}
+	if workers > h {
+		workers = h
+	}
+
+	s.Flush()
+	data := s.GetData()
+	band := (h + workers - 1) / workers
+	var wg sync.WaitGroup
+	for y0 := 0; y0 < h; y0 += band {
+		y1 := y0 + band
+		if y1 > h {
+			y1 = h
+		}
+		wg.Add(1)
+		go func(y0, y1 int) {
+			defer wg.Done()
+			for y := y0; y < y1; y++ {
+				index := s.pixelIndex(0, y)
+				for x := 0; x < w; x++ {
+					encodePixel(data, index, shadePixel(shader, float64(x), float64(y), samples))
+					index += 4
+				}
+			}
+		}(y0, y1)
+	}
+	wg.Wait()
+	s.SetData(data)
+	s.MarkDirty()
+}
+
+// shadePixel returns the color of a single pixel, averaging samples * samples colors.
+func shadePixel(shader ShadeFunc, x, y float64, samples int) color.Color {
+	if samples == 1 {
+		return shader(x, y)
+	}
+	// average in premultiplied space so transparent samples don't bleed their color.
+	r, g, b, a := 0.0, 0.0, 0.0, 0.0
+	step := 1.0 / float64(samples)
+	offset := step/2 - 0.5
+	for i := 0; i < samples; i++ {
+		for j := 0; j < samples; j++ {
+			c := shader(x+offset+float64(i)*step, y+offset+float64(j)*step)
+			r += c.R * c.A
+			g += c.G * c.A
+			b += c.B * c.A
+			a += c.A
+		}
+	}
+	if a == 0 {
+		return color.RGBA(0, 0, 0, 0)
+	}
+	return color.RGBA(r/a, g/a, b/a, a/float64(samples*samples))
+}
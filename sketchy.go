@@ -0,0 +1,238 @@
+package blgo
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/random"
+)
+
+// SketchyStyle holds the settings for drawing shapes in a rough, hand drawn style.
+type SketchyStyle struct {
+	// Roughness is how far lines wander from their true position.
+	Roughness float64
+	// Bowing is how far straight lines bend, relative to their length.
+	Bowing float64
+	// Overshoot is the most that lines run on past their end points.
+	Overshoot float64
+	// Passes is the number of times each line is drawn.
+	Passes int
+	// HachureAngle is the angle of the lines used to fill shapes.
+	HachureAngle float64
+	// HachureGap is the spacing of the lines used to fill shapes.
+	HachureGap float64
+}
+
+// DefaultSketchyStyle returns a SketchyStyle with reasonable settings.
+func DefaultSketchyStyle() *SketchyStyle {
+	return &SketchyStyle{
+		Roughness:    1.0,
+		Bowing:       0.02,
+		Overshoot:    4,
+		Passes:       2,
+		HachureAngle: -math.Pi / 4,
+		HachureGap:   6,
+	}
+}
+
+// SetSketchy turns on sketchy drawing with the given style, or turns it off if style is nil.
+// While it is on, Line, Grid and the Fill and Stroke helpers draw rough double strokes,
+// and fill with hachure lines in place of solid color.
+// The helpers that only add to the path, such as Circle, Path, MultiCurve and BezierPath,
+// are left clean so they can still be used for clipping and custom fills,
+// and Points always draws solid dots, as they are too small to sketch.
+// Lines are jittered afresh each time they are drawn. Call random.Seed before drawing
+// to get the same wobbles on every run.
+func (s *Surface) SetSketchy(style *SketchyStyle) {
+	s.sketchy = style
+}
+
+// Sketchy returns the current sketchy style, or nil if sketchy drawing is off.
+func (s *Surface) Sketchy() *SketchyStyle {
+	return s.sketchy
+}
+
+// roughLine adds a line between two points to the path, once for each pass,
+// with its ends jittered and run on and its middle bowed.
+func (s *Surface) roughLine(x0, y0, x1, y1 float64) {
+	style := s.sketchy
+	length := math.Hypot(x1-x0, y1-y0)
+	if length == 0 {
+		return
+	}
+	dx, dy := (x1-x0)/length, (y1-y0)/length
+	r := style.Roughness
+	for i := 0; i < style.Passes; i++ {
+		start := random.FloatRange(0, style.Overshoot)
+		end := random.FloatRange(0, style.Overshoot)
+		ax := x0 - dx*start + random.FloatRange(-r, r)
+		ay := y0 - dy*start + random.FloatRange(-r, r)
+		bx := x1 + dx*end + random.FloatRange(-r, r)
+		by := y1 + dy*end + random.FloatRange(-r, r)
+		bow := length * style.Bowing * random.FloatRange(-1, 1)
+		s.MoveTo(ax, ay)
+		s.QuadraticCurveTo((ax+bx)/2-dy*bow, (ay+by)/2+dx*bow, bx, by)
+	}
+}
+
+// roughPolyline adds a path of straight lines to the path, drawing each line separately
+// so they cross at the corners.
+func (s *Surface) roughPolyline(points []*geom.Point, closed bool) {
+	for i := 1; i < len(points); i++ {
+		s.roughLine(points[i-1].X, points[i-1].Y, points[i].X, points[i].Y)
+	}
+	if closed && len(points) > 2 {
+		p0, p1 := points[len(points)-1], points[0]
+		s.roughLine(p0.X, p0.Y, p1.X, p1.Y)
+	}
+}
+
+// roughCurve adds a smooth path of points to the path, once for each pass, wobbling it slowly
+// from side to side. Closed curves start at a random point and run on past it.
+func (s *Surface) roughCurve(points []*geom.Point, closed bool) {
+	style := s.sketchy
+	n := len(points)
+	if n < 2 {
+		return
+	}
+	// the distance along the path to each point.
+	lengths := make([]float64, n)
+	for i := 1; i < n; i++ {
+		lengths[i] = lengths[i-1] + points[i-1].Distance(points[i])
+	}
+	total := lengths[n-1]
+	if closed {
+		total += points[n-1].Distance(points[0])
+	}
+	if total == 0 {
+		return
+	}
+	for pass := 0; pass < style.Passes; pass++ {
+		// whole number frequencies, so closed curves meet up with themselves.
+		f0, f1 := float64(random.IntRange(1, 3)), float64(random.IntRange(2, 5))
+		ph0, ph1 := random.FloatRange(0, 2*math.Pi), random.FloatRange(0, 2*math.Pi)
+		wobbled := make([]*geom.Point, n)
+		for i, p := range points {
+			prev, next := p, p
+			if i > 0 {
+				prev = points[i-1]
+			} else if closed {
+				prev = points[n-1]
+			}
+			if i < n-1 {
+				next = points[i+1]
+			} else if closed {
+				next = points[0]
+			}
+			t := lengths[i] / total * 2 * math.Pi
+			offset := style.Roughness * (math.Sin(t*f0+ph0)*0.6 + math.Sin(t*f1+ph1)*0.4)
+			dx, dy := next.X-prev.X, next.Y-prev.Y
+			d := math.Hypot(dx, dy)
+			if d > 0 {
+				dx, dy = dx/d, dy/d
+			}
+			wobbled[i] = geom.NewPoint(p.X-dy*offset, p.Y+dx*offset)
+		}
+		if closed {
+			start := random.IntRange(0, n)
+			path := append(wobbled[start:], wobbled[:start]...)
+			// run on past the start.
+			overshoot := random.FloatRange(0, style.Overshoot)
+			for i, length := 0, 0.0; length < overshoot; i++ {
+				p := wobbled[(start+i)%n]
+				length += path[len(path)-1].Distance(p)
+				path = append(path, p)
+			}
+			wobbled = path
+		}
+		s.NewSubPath()
+		s.Path(wobbled)
+	}
+}
+
+// hachure adds rough lines filling a polygon to the path.
+func (s *Surface) hachure(polygon geom.Polygon) {
+	for _, line := range geom.Hatch(polygon, s.sketchy.HachureAngle, s.sketchy.HachureGap, nil) {
+		s.roughLine(line[0].X, line[0].Y, line[1].X, line[1].Y)
+	}
+}
+
+// ellipsePoints returns points around part of an ellipse, from one angle to another.
+func ellipsePoints(x, y, xr, yr, angle0, angle1 float64) []*geom.Point {
+	steps := int(math.Max(4, math.Ceil(math.Abs(angle1-angle0)*math.Max(xr, yr)/4)))
+	points := make([]*geom.Point, steps+1)
+	for i := range points {
+		angle := angle0 + (angle1-angle0)*float64(i)/float64(steps)
+		points[i] = geom.NewPoint(x+math.Cos(angle)*xr, y+math.Sin(angle)*yr)
+	}
+	return points
+}
+
+func rectanglePoints(x, y, w, h float64) []*geom.Point {
+	return geom.NewRectangle(x, y, w, h).Polygon()[0]
+}
+
+// curveTolerance is how far the points used for rough curves may stray from the true curve.
+// It is well under the roughness, so the wobble hides the corners.
+const curveTolerance = 0.25
+
+// curvePoints returns points along a cubic bezier curve.
+func curvePoints(x0, y0, x1, y1, x2, y2, x3, y3 float64) []*geom.Point {
+	return geom.FlattenBezier(
+		geom.NewPoint(x0, y0), geom.NewPoint(x1, y1),
+		geom.NewPoint(x2, y2), geom.NewPoint(x3, y3),
+		curveTolerance,
+	)
+}
+
+// quadraticPoints returns points along a quadratic curve, raised to a cubic as QuadraticCurveTo does.
+func quadraticPoints(x0, y0, x1, y1, x2, y2 float64) []*geom.Point {
+	return curvePoints(
+		x0, y0,
+		2.0/3.0*x1+1.0/3.0*x0, 2.0/3.0*y1+1.0/3.0*y0,
+		2.0/3.0*x1+1.0/3.0*x2, 2.0/3.0*y1+1.0/3.0*y2,
+		x2, y2,
+	)
+}
+
+// multiCurvePoints returns points along the smooth curve that MultiCurve draws.
+func multiCurvePoints(points []*geom.Point) []*geom.Point {
+	p := points[0]
+	midx, midy := (p.X+points[1].X)/2.0, (p.Y+points[1].Y)/2.0
+	result := []*geom.Point{geom.NewPoint(p.X, p.Y), geom.NewPoint(midx, midy)}
+	for i := 1; i < len(points)-1; i++ {
+		p0, p1 := points[i], points[i+1]
+		x, y := (p0.X+p1.X)/2.0, (p0.Y+p1.Y)/2.0
+		result = append(result, quadraticPoints(midx, midy, p0.X, p0.Y, x, y)[1:]...)
+		midx, midy = x, y
+	}
+	p = points[len(points)-1]
+	return append(result, geom.NewPoint(p.X, p.Y))
+}
+
+// multiLoopPoints returns points around the smooth, closed curve that MultiLoop draws,
+// without repeating the first point at the end.
+func multiLoopPoints(points []*geom.Point) []*geom.Point {
+	pA, pZ := points[0], points[len(points)-1]
+	startx, starty := (pZ.X+pA.X)/2.0, (pZ.Y+pA.Y)/2.0
+	midx, midy := startx, starty
+	result := []*geom.Point{geom.NewPoint(startx, starty)}
+	for i := 0; i < len(points)-1; i++ {
+		p0, p1 := points[i], points[i+1]
+		x, y := (p0.X+p1.X)/2.0, (p0.Y+p1.Y)/2.0
+		result = append(result, quadraticPoints(midx, midy, p0.X, p0.Y, x, y)[1:]...)
+		midx, midy = x, y
+	}
+	last := quadraticPoints(midx, midy, pZ.X, pZ.Y, startx, starty)
+	return append(result, last[1:len(last)-1]...)
+}
+
+// roughBezierPath adds a path of joined cubic bezier curves to the path, as roughCurve does.
+// Closed paths end where they start, so the last point is dropped.
+func (s *Surface) roughBezierPath(path []*geom.Point, closed bool) {
+	points := geom.FlattenBezierPath(path, curveTolerance)
+	if closed && len(points) > 1 {
+		points = points[:len(points)-1]
+	}
+	s.roughCurve(points, closed)
+}
@@ -3,6 +3,7 @@ package blgo
 import (
 	"github.com/bit101/blgo/color"
 	"github.com/bit101/blgo/geom"
+	"github.com/bit101/blgo/vector"
 	cairo "github.com/bit101/go-cairo"
 )
 
@@ -11,14 +12,16 @@ type Surface struct {
 	Width  float64
 	Height float64
 	cairo.Surface
+	recording *vector.Recording
+	sketchy   *SketchyStyle
 }
 
 // NewSurface creates a new Surface.
 func NewSurface(width float64, height float64) *Surface {
 	return &Surface{
-		width,
-		height,
-		*cairo.NewSurface(cairo.FormatARGB32, int(width), int(height)),
+		Width:   width,
+		Height:  height,
+		Surface: *cairo.NewSurface(cairo.FormatARGB32, int(width), int(height)),
 	}
 }
 
@@ -29,18 +32,18 @@ func NewSurfaceFromPNG(filename string) (*Surface, cairo.Status) {
 		return nil, status
 	}
 	return &Surface{
-		float64(surface.GetWidth()),
-		float64(surface.GetHeight()),
-		*surface,
+		Width:   float64(surface.GetWidth()),
+		Height:  float64(surface.GetHeight()),
+		Surface: *surface,
 	}, status
 }
 
 // NewSVGSurface creates a new surface for creating an SVG image. Finish with surface.Finish()
 func NewSVGSurface(filename string, width, height float64) *Surface {
 	return &Surface{
-		width,
-		height,
-		*cairo.NewSVGSurface(filename, width, height, cairo.SVGVersion12),
+		Width:   width,
+		Height:  height,
+		Surface: *cairo.NewSVGSurface(filename, width, height, cairo.SVGVersion12),
 	}
 }
 
@@ -104,10 +107,14 @@ func (s *Surface) SetSourceGray(gray float64) {
 	s.SetSourceRGB(gray, gray, gray)
 }
 
-// GetPixel returns the b, g, r, a value at a given x, y location.
+// GetPixel returns the raw, premultiplied r, g, b, a value at a given x, y location.
 func (s *Surface) GetPixel(x int, y int) (byte, byte, byte, byte) {
+	if !s.InBounds(x, y) {
+		return 0, 0, 0, 0
+	}
+	s.Flush()
 	data := s.GetData()
-	index := (y*s.GetWidth() + x) * 4
+	index := s.pixelIndex(x, y)
 	return data[index+2], data[index+1], data[index], data[index+3]
 }
 
@@ -0,0 +1,225 @@
+// Package turtle provides turtle graphics, drawing onto a blgo.Surface
+// and recording each line drawn as geometry.
+package turtle
+
+import (
+	"math"
+
+	"github.com/bit101/blgo"
+	"github.com/bit101/blgo/color"
+	"github.com/bit101/blgo/geom"
+)
+
+// Line is a path drawn by the turtle, with the color and width of the pen that drew it.
+type Line struct {
+	Points []*geom.Point
+	Color  color.Color
+	Width  float64
+}
+
+type state struct {
+	x, y, heading float64
+	penDown       bool
+	color         color.Color
+	width         float64
+}
+
+// Turtle is a pen that moves and turns, drawing as it goes.
+// Headings are in radians unless SetDegrees is used, starting at 0 for facing right.
+// As y points down on screen, turning right turns clockwise.
+type Turtle struct {
+	X, Y    float64
+	heading float64
+	degrees bool
+	penDown bool
+	color   color.Color
+	width   float64
+	stack   []state
+	lines   []*Line
+	current *Line
+	surface *blgo.Surface
+}
+
+// NewTurtle creates a new turtle at x, y, facing right with its pen down.
+// If surface is not nil, each line is stroked onto it as soon as it is finished.
+// Otherwise lines are only recorded, to be drawn later with Draw, or exported with Lines and Paths.
+func NewTurtle(surface *blgo.Surface, x, y float64) *Turtle {
+	return &Turtle{
+		X:       x,
+		Y:       y,
+		penDown: true,
+		color:   color.Black(),
+		width:   1,
+		surface: surface,
+	}
+}
+
+// SetDegrees sets whether headings and turns are given in degrees rather than radians.
+func (t *Turtle) SetDegrees(degrees bool) {
+	t.degrees = degrees
+}
+
+func (t *Turtle) toRadians(angle float64) float64 {
+	if t.degrees {
+		return angle * math.Pi / 180
+	}
+	return angle
+}
+
+// Heading returns the direction the turtle is facing.
+func (t *Turtle) Heading() float64 {
+	if t.degrees {
+		return t.heading * 180 / math.Pi
+	}
+	return t.heading
+}
+
+// SetHeading turns the turtle to face a direction.
+func (t *Turtle) SetHeading(heading float64) {
+	t.heading = t.toRadians(heading)
+}
+
+// Left turns the turtle anticlockwise on screen.
+func (t *Turtle) Left(angle float64) {
+	t.heading -= t.toRadians(angle)
+}
+
+// Right turns the turtle clockwise on screen.
+func (t *Turtle) Right(angle float64) {
+	t.heading += t.toRadians(angle)
+}
+
+// Forward moves the turtle forward, drawing a line if the pen is down.
+func (t *Turtle) Forward(distance float64) {
+	t.MoveTo(t.X+math.Cos(t.heading)*distance, t.Y+math.Sin(t.heading)*distance)
+}
+
+// Back moves the turtle backwards without turning, drawing a line if the pen is down.
+func (t *Turtle) Back(distance float64) {
+	t.Forward(-distance)
+}
+
+// MoveTo moves the turtle straight to x, y without turning, drawing a line if the pen is down.
+func (t *Turtle) MoveTo(x, y float64) {
+	if t.penDown {
+		if t.current == nil {
+			t.current = &Line{
+				Points: []*geom.Point{geom.NewPoint(t.X, t.Y)},
+				Color:  t.color,
+				Width:  t.width,
+			}
+		}
+		t.current.Points = append(t.current.Points, geom.NewPoint(x, y))
+	}
+	t.X = x
+	t.Y = y
+}
+
+// Jump moves the turtle to x, y without drawing.
+func (t *Turtle) Jump(x, y float64) {
+	t.Finish()
+	t.X = x
+	t.Y = y
+}
+
+// PenUp lifts the pen, so the turtle moves without drawing.
+func (t *Turtle) PenUp() {
+	t.Finish()
+	t.penDown = false
+}
+
+// PenDown lowers the pen, so the turtle draws as it moves.
+func (t *Turtle) PenDown() {
+	t.penDown = true
+}
+
+// IsPenDown returns whether the pen is down.
+func (t *Turtle) IsPenDown() bool {
+	return t.penDown
+}
+
+// SetColor changes the pen color. It applies from the next line drawn.
+func (t *Turtle) SetColor(c color.Color) {
+	t.Finish()
+	t.color = c
+}
+
+// SetWidth changes the pen width. It applies from the next line drawn.
+func (t *Turtle) SetWidth(width float64) {
+	t.Finish()
+	t.width = width
+}
+
+// Push saves the turtle's position, heading and pen on a stack.
+func (t *Turtle) Push() {
+	t.stack = append(t.stack, state{t.X, t.Y, t.heading, t.penDown, t.color, t.width})
+}
+
+// Pop restores the turtle's position, heading and pen from the last Push, without drawing.
+func (t *Turtle) Pop() {
+	if len(t.stack) == 0 {
+		return
+	}
+	t.Finish()
+	s := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	t.X, t.Y, t.heading = s.x, s.y, s.heading
+	t.penDown, t.color, t.width = s.penDown, s.color, s.width
+}
+
+// Finish ends the line being drawn, stroking it onto the turtle's surface if it has one.
+// Lines end by themselves when the pen lifts or changes, or the turtle jumps or pops.
+// Call Finish once done, to complete the last line.
+func (t *Turtle) Finish() {
+	if t.current == nil {
+		return
+	}
+	t.lines = append(t.lines, t.current)
+	if t.surface != nil {
+		DrawLines(t.surface, []*Line{t.current})
+	}
+	t.current = nil
+}
+
+// Lines returns every line drawn so far, including any line not yet finished.
+func (t *Turtle) Lines() []*Line {
+	if t.current != nil {
+		return append(t.lines[:len(t.lines):len(t.lines)], t.current)
+	}
+	return t.lines
+}
+
+// Paths returns the points of every line drawn so far.
+func (t *Turtle) Paths() [][]*geom.Point {
+	var paths [][]*geom.Point
+	for _, line := range t.Lines() {
+		paths = append(paths, line.Points)
+	}
+	return paths
+}
+
+// Bounds returns the smallest rectangle containing every line drawn so far.
+func (t *Turtle) Bounds() *geom.Rectangle {
+	var points []*geom.Point
+	for _, line := range t.Lines() {
+		points = append(points, line.Points...)
+	}
+	return geom.BoundingBox(points)
+}
+
+// Draw strokes every line drawn so far onto a surface, in its own color and width.
+func (t *Turtle) Draw(surface *blgo.Surface) {
+	DrawLines(surface, t.Lines())
+}
+
+// DrawLines strokes lines onto a surface, each in its own color and width.
+func DrawLines(surface *blgo.Surface, lines []*Line) {
+	for _, line := range lines {
+		surface.Save()
+		surface.SetSourceColor(line.Color)
+		surface.SetLineWidth(line.Width)
+		surface.NewPath()
+		surface.StrokePath(line.Points, false)
+		surface.Restore()
+	}
+}
@@ -0,0 +1,62 @@
+package turtle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bit101/blgo/color"
+)
+
+func TestSquare(t *testing.T) {
+	turtle := NewTurtle(nil, 0, 0)
+	turtle.SetDegrees(true)
+	for i := 0; i < 4; i++ {
+		turtle.Forward(10)
+		turtle.Right(90)
+	}
+	turtle.Finish()
+	lines := turtle.Lines()
+	if len(lines) != 1 || len(lines[0].Points) != 5 {
+		t.Fatalf("square drew %d lines, want one of 5 points", len(lines))
+	}
+	// turning right goes clockwise on screen, so the second side heads down.
+	if p := lines[0].Points[2]; math.Abs(p.X-10) > 1e-9 || math.Abs(p.Y-10) > 1e-9 {
+		t.Errorf("second corner %v, want 10, 10", p)
+	}
+	if math.Abs(turtle.X) > 1e-9 || math.Abs(turtle.Y) > 1e-9 {
+		t.Errorf("turtle ended at %f, %f, want 0, 0", turtle.X, turtle.Y)
+	}
+	if math.Abs(turtle.Heading()-360) > 1e-9 {
+		t.Errorf("heading %f, want 360", turtle.Heading())
+	}
+}
+
+func TestPenAndStack(t *testing.T) {
+	turtle := NewTurtle(nil, 0, 0)
+	turtle.Forward(10)
+	turtle.PenUp()
+	turtle.Forward(10)
+	turtle.PenDown()
+	turtle.Push()
+	turtle.Left(math.Pi / 2)
+	turtle.Forward(10)
+	turtle.Pop()
+	turtle.SetColor(color.RGB(1, 0, 0))
+	turtle.Forward(5)
+
+	lines := turtle.Lines()
+	if len(lines) != 3 {
+		t.Fatalf("drew %d lines, want 3", len(lines))
+	}
+	if p := lines[1].Points[1]; math.Abs(p.X-20) > 1e-9 || math.Abs(p.Y+10) > 1e-9 {
+		t.Errorf("left turn went to %v, want 20, -10", p)
+	}
+	if lines[2].Color.R != 1 || lines[2].Points[0].X != 20 {
+		t.Errorf("line after pop starts at %v in %v", lines[2].Points[0], lines[2].Color)
+	}
+
+	bounds := turtle.Bounds()
+	if bounds.X != 0 || bounds.W != 25 || bounds.Y != -10 || bounds.H != 10 {
+		t.Errorf("bounds %v, want 0, -10, 25, 10", bounds)
+	}
+}
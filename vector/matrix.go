@@ -0,0 +1,47 @@
+package vector
+
+import "math"
+
+// matrix is a 2d affine transform, matching cairo's xx, yx, xy, yy, x0, y0 layout.
+type matrix struct {
+	xx, yx, xy, yy, x0, y0 float64
+}
+
+func identity() matrix {
+	return matrix{1, 0, 0, 1, 0, 0}
+}
+
+// multiply returns the transform m applied before n.
+func (m matrix) multiply(n matrix) matrix {
+	return matrix{
+		xx: m.xx*n.xx + m.yx*n.xy,
+		yx: m.xx*n.yx + m.yx*n.yy,
+		xy: m.xy*n.xx + m.yy*n.xy,
+		yy: m.xy*n.yx + m.yy*n.yy,
+		x0: m.x0*n.xx + m.y0*n.xy + n.x0,
+		y0: m.x0*n.yx + m.y0*n.yy + n.y0,
+	}
+}
+
+func (m matrix) translate(x, y float64) matrix {
+	return matrix{1, 0, 0, 1, x, y}.multiply(m)
+}
+
+func (m matrix) scale(x, y float64) matrix {
+	return matrix{x, 0, 0, y, 0, 0}.multiply(m)
+}
+
+func (m matrix) rotate(angle float64) matrix {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return matrix{c, s, -s, c, 0, 0}.multiply(m)
+}
+
+// apply transforms a user space point to device space.
+func (m matrix) apply(x, y float64) (float64, float64) {
+	return m.xx*x + m.xy*y + m.x0, m.yx*x + m.yy*y + m.y0
+}
+
+// scaleFactor returns the average amount the transform scales lengths by.
+func (m matrix) scaleFactor() float64 {
+	return math.Sqrt(math.Abs(m.xx*m.yy - m.xy*m.yx))
+}
@@ -0,0 +1,76 @@
+// Package vector records drawing commands into an in-memory path model
+// that can be exported as SVG or replayed onto a surface.
+package vector
+
+import (
+	"github.com/bit101/blgo/color"
+	"github.com/bit101/blgo/geom"
+)
+
+// Op is the type of a path segment.
+type Op int
+
+const (
+	// OpMoveTo starts a new sub path at a point.
+	OpMoveTo Op = iota
+	// OpLineTo draws a straight line to a point.
+	OpLineTo
+	// OpCurveTo draws a cubic bezier curve with two control points and an end point.
+	OpCurveTo
+	// OpClose draws a line back to the start of the current sub path.
+	OpClose
+)
+
+// Segment is a single path operation. Points holds one point for OpMoveTo and OpLineTo,
+// three for OpCurveTo and none for OpClose.
+type Segment struct {
+	Op     Op
+	Points []*geom.Point
+}
+
+// Shape is a path that has been stroked or filled, with all points in device space.
+type Shape struct {
+	Segments  []Segment
+	Color     color.Color
+	LineWidth float64
+	Filled    bool
+}
+
+// Polylines returns the sub paths of the shape as lists of points.
+// Curves are flattened so that no point is further than tolerance from the true curve.
+// Closed sub paths end with a copy of their first point.
+func (s *Shape) Polylines(tolerance float64) [][]*geom.Point {
+	var lines [][]*geom.Point
+	var line []*geom.Point
+	var start, current *geom.Point
+	flush := func() {
+		if len(line) > 1 {
+			lines = append(lines, line)
+		}
+		line = nil
+	}
+	for _, seg := range s.Segments {
+		switch seg.Op {
+		case OpMoveTo:
+			flush()
+			start = seg.Points[0]
+			current = start
+			line = []*geom.Point{start}
+		case OpLineTo:
+			current = seg.Points[0]
+			line = append(line, current)
+		case OpCurveTo:
+			line = append(line, geom.FlattenBezier(current, seg.Points[0], seg.Points[1], seg.Points[2], tolerance)[1:]...)
+			current = seg.Points[2]
+		case OpClose:
+			if start != nil {
+				line = append(line, geom.NewPoint(start.X, start.Y))
+				current = start
+			}
+			flush()
+			line = []*geom.Point{start}
+		}
+	}
+	flush()
+	return lines
+}
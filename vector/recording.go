@@ -0,0 +1,238 @@
+package vector
+
+import (
+	"math"
+
+	"github.com/bit101/blgo/blmath"
+	"github.com/bit101/blgo/color"
+	"github.com/bit101/blgo/geom"
+)
+
+type state struct {
+	matrix    matrix
+	color     color.Color
+	lineWidth float64
+}
+
+// Recording captures drawing commands as shapes in device space.
+// Its methods mirror the cairo drawing methods of the same names.
+type Recording struct {
+	Width  float64
+	Height float64
+	Shapes []*Shape
+	state
+	stack   []state
+	path    []Segment
+	start   *geom.Point
+	current *geom.Point
+}
+
+// NewRecording creates a new, empty recording.
+func NewRecording(width, height float64) *Recording {
+	return &Recording{
+		Width:  width,
+		Height: height,
+		state: state{
+			matrix:    identity(),
+			color:     color.Black(),
+			lineWidth: 2.0,
+		},
+	}
+}
+
+// Save pushes the current transform, color and line width onto a stack.
+func (r *Recording) Save() {
+	r.stack = append(r.stack, r.state)
+}
+
+// Restore pops the last saved transform, color and line width off the stack.
+func (r *Recording) Restore() {
+	if len(r.stack) == 0 {
+		return
+	}
+	r.state = r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+}
+
+// Translate translates the current transform.
+func (r *Recording) Translate(x, y float64) {
+	r.matrix = r.matrix.translate(x, y)
+}
+
+// Rotate rotates the current transform.
+func (r *Recording) Rotate(angle float64) {
+	r.matrix = r.matrix.rotate(angle)
+}
+
+// Scale scales the current transform.
+func (r *Recording) Scale(x, y float64) {
+	r.matrix = r.matrix.scale(x, y)
+}
+
+// IdentityMatrix resets the current transform.
+func (r *Recording) IdentityMatrix() {
+	r.matrix = identity()
+}
+
+// SetMatrix sets the current transform, given in cairo's xx, yx, xy, yy, x0, y0 order.
+func (r *Recording) SetMatrix(xx, yx, xy, yy, x0, y0 float64) {
+	r.matrix = matrix{xx, yx, xy, yy, x0, y0}
+}
+
+// SetColor sets the color used for shapes stroked or filled after this call.
+func (r *Recording) SetColor(c color.Color) {
+	r.color = c
+}
+
+// SetLineWidth sets the line width, in user space, for shapes stroked after this call.
+func (r *Recording) SetLineWidth(width float64) {
+	r.lineWidth = width
+}
+
+// MoveTo begins a new sub path.
+func (r *Recording) MoveTo(x, y float64) {
+	p := r.device(x, y)
+	r.path = append(r.path, Segment{OpMoveTo, []*geom.Point{p}})
+	r.start = p
+	r.current = p
+}
+
+// LineTo adds a line to the path. With no current point, it acts as MoveTo.
+func (r *Recording) LineTo(x, y float64) {
+	if r.current == nil {
+		r.MoveTo(x, y)
+		return
+	}
+	p := r.device(x, y)
+	r.path = append(r.path, Segment{OpLineTo, []*geom.Point{p}})
+	r.current = p
+}
+
+// CurveTo adds a cubic bezier curve to the path.
+func (r *Recording) CurveTo(x0, y0, x1, y1, x2, y2 float64) {
+	if r.current == nil {
+		r.MoveTo(x0, y0)
+	}
+	p := r.device(x2, y2)
+	r.path = append(r.path, Segment{OpCurveTo, []*geom.Point{r.device(x0, y0), r.device(x1, y1), p}})
+	r.current = p
+}
+
+// Arc adds a clockwise circular arc to the path, converted to bezier curves.
+func (r *Recording) Arc(x, y, radius, angle0, angle1 float64) {
+	for angle1 < angle0 {
+		angle1 += blmath.TwoPi
+	}
+	r.arc(x, y, radius, angle0, angle1)
+}
+
+// ArcNegative adds a counter clockwise circular arc to the path, converted to bezier curves.
+func (r *Recording) ArcNegative(x, y, radius, angle0, angle1 float64) {
+	for angle1 > angle0 {
+		angle1 -= blmath.TwoPi
+	}
+	r.arc(x, y, radius, angle0, angle1)
+}
+
+func (r *Recording) arc(x, y, radius, angle0, angle1 float64) {
+	startX, startY := x+math.Cos(angle0)*radius, y+math.Sin(angle0)*radius
+	if r.current == nil {
+		r.MoveTo(startX, startY)
+	} else {
+		r.LineTo(startX, startY)
+	}
+	// split into pieces of no more than 90 degrees, each approximated by one bezier curve.
+	count := int(math.Ceil(math.Abs(angle1-angle0) / blmath.HalfPi))
+	if count == 0 {
+		return
+	}
+	step := (angle1 - angle0) / float64(count)
+	k := 4.0 / 3.0 * math.Tan(step/4) * radius
+	for i := 0; i < count; i++ {
+		a0 := angle0 + step*float64(i)
+		a1 := a0 + step
+		cos0, sin0 := math.Cos(a0), math.Sin(a0)
+		cos1, sin1 := math.Cos(a1), math.Sin(a1)
+		r.CurveTo(
+			x+cos0*radius-sin0*k, y+sin0*radius+cos0*k,
+			x+cos1*radius+sin1*k, y+sin1*radius-cos1*k,
+			x+cos1*radius, y+sin1*radius,
+		)
+	}
+}
+
+// Rectangle adds a closed rectangle sub path.
+func (r *Recording) Rectangle(x, y, w, h float64) {
+	r.MoveTo(x, y)
+	r.LineTo(x+w, y)
+	r.LineTo(x+w, y+h)
+	r.LineTo(x, y+h)
+	r.ClosePath()
+}
+
+// ClosePath closes the current sub path.
+func (r *Recording) ClosePath() {
+	if r.current == nil {
+		return
+	}
+	r.path = append(r.path, Segment{Op: OpClose})
+	r.current = r.start
+}
+
+// NewPath clears the current path.
+func (r *Recording) NewPath() {
+	r.path = nil
+	r.start = nil
+	r.current = nil
+}
+
+// NewSubPath ends the current sub path without closing it.
+func (r *Recording) NewSubPath() {
+	r.current = nil
+}
+
+// Stroke records the current path as a stroked shape and clears the path.
+func (r *Recording) Stroke() {
+	r.StrokePreserve()
+	r.NewPath()
+}
+
+// StrokePreserve records the current path as a stroked shape.
+func (r *Recording) StrokePreserve() {
+	r.addShape(false)
+}
+
+// Fill records the current path as a filled shape and clears the path.
+func (r *Recording) Fill() {
+	r.FillPreserve()
+	r.NewPath()
+}
+
+// FillPreserve records the current path as a filled shape.
+func (r *Recording) FillPreserve() {
+	r.addShape(true)
+}
+
+// Clear removes all recorded shapes.
+func (r *Recording) Clear() {
+	r.Shapes = nil
+	r.NewPath()
+}
+
+func (r *Recording) addShape(filled bool) {
+	if len(r.path) == 0 {
+		return
+	}
+	segments := make([]Segment, len(r.path))
+	copy(segments, r.path)
+	r.Shapes = append(r.Shapes, &Shape{
+		Segments:  segments,
+		Color:     r.color,
+		LineWidth: r.lineWidth * r.matrix.scaleFactor(),
+		Filled:    filled,
+	})
+}
+
+func (r *Recording) device(x, y float64) *geom.Point {
+	return geom.NewPoint(r.matrix.apply(x, y))
+}
@@ -0,0 +1,143 @@
+package vector
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bit101/blgo/color"
+)
+
+// Layer is a group of shapes that share a color, such as a single pen on a plotter.
+type Layer struct {
+	Name   string
+	Color  color.Color
+	Shapes []*Shape
+}
+
+// Layers groups the recorded shapes by color, in the order each color was first used.
+// Each layer is named by the hex value of its color.
+func (r *Recording) Layers() []*Layer {
+	var layers []*Layer
+	byName := map[string]*Layer{}
+	for _, shape := range r.Shapes {
+		name := hexColor(shape.Color)
+		layer, ok := byName[name]
+		if !ok {
+			layer = &Layer{
+				Name:  name,
+				Color: shape.Color,
+			}
+			byName[name] = layer
+			layers = append(layers, layer)
+		}
+		layer.Shapes = append(layer.Shapes, shape)
+	}
+	return layers
+}
+
+// WriteSVG writes the recording as an SVG document with one group per layer.
+// Groups are marked as Inkscape layers so plotter software can select them by pen.
+func (r *Recording) WriteSVG(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" "+
+		"xmlns:inkscape=\"http://www.inkscape.org/namespaces/inkscape\" "+
+		"width=\"%s\" height=\"%s\" viewBox=\"0 0 %s %s\">\n",
+		num(r.Width), num(r.Height), num(r.Width), num(r.Height))
+	for i, layer := range r.Layers() {
+		fmt.Fprintf(bw, "  <g id=\"layer%d\" inkscape:groupmode=\"layer\" inkscape:label=\"%d %s\">\n",
+			i+1, i+1, layer.Name)
+		for _, shape := range layer.Shapes {
+			fmt.Fprintf(bw, "    <path d=\"%s\" %s/>\n", pathData(shape.Segments), styleAttributes(shape))
+		}
+		fmt.Fprintf(bw, "  </g>\n")
+	}
+	fmt.Fprintf(bw, "</svg>\n")
+	return bw.Flush()
+}
+
+// WriteSVGFile writes the recording to an SVG file.
+func (r *Recording) WriteSVGFile(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = r.WriteSVG(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// pathData converts segments to the contents of an SVG path's d attribute.
+func pathData(segments []Segment) string {
+	var sb strings.Builder
+	for i, seg := range segments {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		switch seg.Op {
+		case OpMoveTo:
+			sb.WriteString("M")
+		case OpLineTo:
+			sb.WriteString("L")
+		case OpCurveTo:
+			sb.WriteString("C")
+		case OpClose:
+			sb.WriteString("Z")
+		}
+		for j, p := range seg.Points {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(num(p.X))
+			sb.WriteByte(',')
+			sb.WriteString(num(p.Y))
+		}
+	}
+	return sb.String()
+}
+
+func styleAttributes(shape *Shape) string {
+	opacity := ""
+	if shape.Color.A < 1 {
+		opacity = num(math.Max(shape.Color.A, 0))
+	}
+	if shape.Filled {
+		attrs := fmt.Sprintf("fill=\"%s\" stroke=\"none\"", hexColor(shape.Color))
+		if opacity != "" {
+			attrs += fmt.Sprintf(" fill-opacity=\"%s\"", opacity)
+		}
+		return attrs
+	}
+	attrs := fmt.Sprintf("fill=\"none\" stroke=\"%s\" stroke-width=\"%s\"", hexColor(shape.Color), num(shape.LineWidth))
+	if opacity != "" {
+		attrs += fmt.Sprintf(" stroke-opacity=\"%s\"", opacity)
+	}
+	return attrs
+}
+
+// hexColor returns a color as an #rrggbb string, ignoring alpha.
+func hexColor(c color.Color) string {
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", channel(c.R), channel(c.G), channel(c.B))
+}
+
+// num formats a number with at most three decimal places and no trailing zeros.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 3, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
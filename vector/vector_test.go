@@ -0,0 +1,162 @@
+package vector
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/bit101/blgo/color"
+)
+
+func TestTransform(t *testing.T) {
+	r := NewRecording(100, 100)
+	r.Translate(10, 20)
+	r.Rotate(math.Pi / 2)
+	r.Scale(2, 2)
+	r.MoveTo(5, 0)
+	r.LineTo(0, 5)
+	r.Stroke()
+
+	p := r.Shapes[0].Segments[0].Points[0]
+	if math.Abs(p.X-10) > 1e-9 || math.Abs(p.Y-30) > 1e-9 {
+		t.Errorf("MoveTo(5, 0) transformed to %v, want 10, 30", p)
+	}
+	p = r.Shapes[0].Segments[1].Points[0]
+	if math.Abs(p.X-0) > 1e-9 || math.Abs(p.Y-20) > 1e-9 {
+		t.Errorf("LineTo(0, 5) transformed to %v, want 0, 20", p)
+	}
+	if r.Shapes[0].LineWidth != 4 {
+		t.Errorf("line width %f, want 4", r.Shapes[0].LineWidth)
+	}
+}
+
+func TestSetMatrix(t *testing.T) {
+	r := NewRecording(100, 100)
+	r.SetMatrix(0, 2, -2, 0, 10, 20)
+	r.MoveTo(5, 0)
+	r.LineTo(0, 5)
+	r.Stroke()
+
+	p := r.Shapes[0].Segments[0].Points[0]
+	if math.Abs(p.X-10) > 1e-9 || math.Abs(p.Y-30) > 1e-9 {
+		t.Errorf("MoveTo(5, 0) transformed to %v, want 10, 30", p)
+	}
+	p = r.Shapes[0].Segments[1].Points[0]
+	if math.Abs(p.X-0) > 1e-9 || math.Abs(p.Y-20) > 1e-9 {
+		t.Errorf("LineTo(0, 5) transformed to %v, want 0, 20", p)
+	}
+
+	// later transforms build on the set matrix.
+	r.Translate(1, 0)
+	r.MoveTo(0, 0)
+	r.LineTo(1, 1)
+	r.Stroke()
+	if p := r.Shapes[1].Segments[0].Points[0]; math.Abs(p.X-10) > 1e-9 || math.Abs(p.Y-22) > 1e-9 {
+		t.Errorf("translated MoveTo(0, 0) transformed to %v, want 10, 22", p)
+	}
+}
+
+func TestSaveRestore(t *testing.T) {
+	r := NewRecording(100, 100)
+	r.Save()
+	r.Translate(50, 50)
+	r.SetColor(color.Red())
+	r.Restore()
+	r.MoveTo(0, 0)
+	r.LineTo(1, 1)
+	r.Stroke()
+
+	p := r.Shapes[0].Segments[0].Points[0]
+	if p.X != 0 || p.Y != 0 {
+		t.Errorf("transform not restored: %v", p)
+	}
+	if r.Shapes[0].Color != color.Black() {
+		t.Errorf("color not restored: %v", r.Shapes[0].Color)
+	}
+}
+
+func TestArc(t *testing.T) {
+	r := NewRecording(100, 100)
+	r.Arc(50, 50, 20, 0, math.Pi*2)
+	r.Stroke()
+
+	lines := r.Shapes[0].Polylines(0.01)
+	if len(lines) != 1 {
+		t.Fatalf("got %d polylines, want 1", len(lines))
+	}
+	for _, p := range lines[0] {
+		d := math.Hypot(p.X-50, p.Y-50)
+		if math.Abs(d-20) > 0.05 {
+			t.Errorf("point %v is %f from center, want 20", p, d)
+		}
+	}
+}
+
+func TestLineToWithoutCurrentPoint(t *testing.T) {
+	r := NewRecording(100, 100)
+	r.LineTo(10, 10)
+	r.LineTo(20, 20)
+	r.Stroke()
+	if r.Shapes[0].Segments[0].Op != OpMoveTo {
+		t.Errorf("first LineTo should act as MoveTo")
+	}
+}
+
+func TestPolylinesClose(t *testing.T) {
+	r := NewRecording(100, 100)
+	r.Rectangle(0, 0, 10, 10)
+	r.Stroke()
+	lines := r.Shapes[0].Polylines(0.1)
+	if len(lines) != 1 || len(lines[0]) != 5 {
+		t.Fatalf("rectangle polyline %v, want 5 points", lines)
+	}
+	first, last := lines[0][0], lines[0][4]
+	if first.X != last.X || first.Y != last.Y {
+		t.Errorf("closed polyline does not end at start")
+	}
+}
+
+func TestLayers(t *testing.T) {
+	r := NewRecording(100, 100)
+	for _, c := range []color.Color{color.Red(), color.Blue(), color.Red()} {
+		r.SetColor(c)
+		r.MoveTo(0, 0)
+		r.LineTo(10, 10)
+		r.Stroke()
+	}
+	layers := r.Layers()
+	if len(layers) != 2 {
+		t.Fatalf("got %d layers, want 2", len(layers))
+	}
+	if layers[0].Name != "#ff0000" || len(layers[0].Shapes) != 2 {
+		t.Errorf("first layer %s has %d shapes", layers[0].Name, len(layers[0].Shapes))
+	}
+	if layers[1].Name != "#0000ff" || len(layers[1].Shapes) != 1 {
+		t.Errorf("second layer %s has %d shapes", layers[1].Name, len(layers[1].Shapes))
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	r := NewRecording(100, 50)
+	r.SetLineWidth(0.5)
+	r.MoveTo(1.25, 2)
+	r.LineTo(10, 20)
+	r.Stroke()
+	var buf bytes.Buffer
+	err := r.WriteSVG(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	for _, want := range []string{
+		`viewBox="0 0 100 50"`,
+		`inkscape:groupmode="layer"`,
+		`d="M1.25,2 L10,20"`,
+		`stroke-width="0.5"`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg missing %s:\n%s", want, svg)
+		}
+	}
+}